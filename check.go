@@ -0,0 +1,279 @@
+package hyprls
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ewen-lbh/hyprls/parser"
+	"go.lsp.dev/protocol"
+)
+
+// SeverityOff disables a rule when used in CheckOptions.Severities
+const SeverityOff protocol.DiagnosticSeverity = 0
+
+// CheckOptions configures Check
+type CheckOptions struct {
+	// Severities overrides the severity of the diagnostics emitted by a rule, by rule code. Use SeverityOff to disable a rule. Check returns an error for codes that are not in DiagnosticRuleCodes.
+	Severities map[string]protocol.DiagnosticSeverity
+	// Version is the Hyprland version configurations target when their file doesn't declare one, see parser.TargetVersion. Empty means any version.
+	Version string
+}
+
+// FileDiagnostics are the diagnostics of a configuration file
+type FileDiagnostics struct {
+	Path        string                `json:"path"`
+	Diagnostics []protocol.Diagnostic `json:"diagnostics"`
+}
+
+// Check runs the same diagnostics as the language server on the configuration files at paths, and on every file they source.
+// Files are returned sorted by path, and every file appears only once, even if it is part of multiple configurations.
+func Check(paths []string, options CheckOptions) ([]FileDiagnostics, error) {
+	for code := range options.Severities {
+		if !slices.Contains(DiagnosticRuleCodes(), code) {
+			return nil, fmt.Errorf("unknown rule %q, expected one of %s", code, strings.Join(DiagnosticRuleCodes(), ", "))
+		}
+	}
+
+	checked := make(map[string]FileDiagnostics)
+	for _, path := range paths {
+		graph, err := parser.LoadGraph(path, readFile)
+		if err != nil {
+			return nil, fmt.Errorf("while loading %s: %w", path, err)
+		}
+
+		keybinds := analyzeKeybinds(graph)
+		for _, file := range graph.SortedFiles() {
+			if _, ok := checked[file.Path]; ok {
+				continue
+			}
+			version := graph.TargetVersion(file.Path)
+			if version == "" {
+				version = options.Version
+			}
+			checked[file.Path] = FileDiagnostics{
+				Path:        file.Path,
+				Diagnostics: options.apply(diagnose(diagnosticContext{File: file, Graph: graph, Version: version, Keybinds: keybinds})),
+			}
+		}
+	}
+
+	results := make([]FileDiagnostics, 0, len(checked))
+	for _, result := range checked {
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Path < results[j].Path
+	})
+	return results, nil
+}
+
+func (options CheckOptions) apply(diagnostics []protocol.Diagnostic) []protocol.Diagnostic {
+	filtered := make([]protocol.Diagnostic, 0, len(diagnostics))
+	for _, diag := range diagnostics {
+		if severity, ok := options.Severities[fmt.Sprint(diag.Code)]; ok {
+			if severity == SeverityOff {
+				continue
+			}
+			diag.Severity = severity
+		}
+		filtered = append(filtered, diag)
+	}
+	return filtered
+}
+
+// HasErrors returns true if any of the diagnostics is an error
+func HasErrors(results []FileDiagnostics) bool {
+	for _, result := range results {
+		for _, diag := range result.Diagnostics {
+			if diag.Severity == protocol.DiagnosticSeverityError {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ParseSeverity parses a severity name: error, warning, info, hint or off
+func ParseSeverity(name string) (protocol.DiagnosticSeverity, error) {
+	switch strings.ToLower(name) {
+	case "error":
+		return protocol.DiagnosticSeverityError, nil
+	case "warning", "warn":
+		return protocol.DiagnosticSeverityWarning, nil
+	case "info", "information":
+		return protocol.DiagnosticSeverityInformation, nil
+	case "hint":
+		return protocol.DiagnosticSeverityHint, nil
+	case "off", "none":
+		return SeverityOff, nil
+	default:
+		return SeverityOff, fmt.Errorf("unknown severity %q, use one of error, warning, info, hint or off", name)
+	}
+}
+
+// DiagnosticRuleCodes returns the codes of all the rules diagnostics are checked against
+func DiagnosticRuleCodes() []string {
+	codes := make([]string, 0, len(diagnosticRules))
+	for _, rule := range diagnosticRules {
+		codes = append(codes, rule.Code)
+	}
+	return codes
+}
+
+// WriteHumanReport writes the diagnostics in a compiler-like format, one per line: path:line:column: severity: message [code]
+func WriteHumanReport(w io.Writer, results []FileDiagnostics) error {
+	for _, result := range results {
+		for _, diag := range result.Diagnostics {
+			_, err := fmt.Fprintf(w, "%s:%d:%d: %s: %s [%v]\n", displayPath(result.Path), diag.Range.Start.Line+1, diag.Range.Start.Character+1, strings.ToLower(diag.Severity.String()), diag.Message, diag.Code)
+			if err != nil {
+				return err
+			}
+			for _, related := range diag.RelatedInformation {
+				_, err := fmt.Fprintf(w, "\t%s:%d:%d: %s\n", displayPath(related.Location.URI.Filename()), related.Location.Range.Start.Line+1, related.Location.Range.Start.Character+1, related.Message)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// WriteJSONReport writes the diagnostics as a JSON array of FileDiagnostics
+func WriteJSONReport(w io.Writer, results []FileDiagnostics) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(results)
+}
+
+// WriteSARIFReport writes the diagnostics as a SARIF 2.1.0 log, which is understood by code scanning tools of CI platforms.
+// Reference: https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+func WriteSARIFReport(w io.Writer, results []FileDiagnostics) error {
+	type sarifMessage struct {
+		Text string `json:"text"`
+	}
+	type sarifRegion struct {
+		StartLine   uint32 `json:"startLine"`
+		StartColumn uint32 `json:"startColumn"`
+		EndLine     uint32 `json:"endLine"`
+		EndColumn   uint32 `json:"endColumn"`
+	}
+	type sarifPhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region sarifRegion `json:"region"`
+	}
+	type sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+		Message          *sarifMessage         `json:"message,omitempty"`
+	}
+	type sarifResult struct {
+		RuleID           string          `json:"ruleId"`
+		Level            string          `json:"level"`
+		Message          sarifMessage    `json:"message"`
+		Locations        []sarifLocation `json:"locations"`
+		RelatedLocations []sarifLocation `json:"relatedLocations,omitempty"`
+	}
+	type sarifRule struct {
+		ID                   string `json:"id"`
+		DefaultConfiguration struct {
+			Level string `json:"level"`
+		} `json:"defaultConfiguration"`
+	}
+
+	location := func(path string, rang protocol.Range, message string) sarifLocation {
+		loc := sarifLocation{}
+		loc.PhysicalLocation.ArtifactLocation.URI = filepath.ToSlash(displayPath(path))
+		loc.PhysicalLocation.Region = sarifRegion{
+			StartLine:   rang.Start.Line + 1,
+			StartColumn: rang.Start.Character + 1,
+			EndLine:     rang.End.Line + 1,
+			EndColumn:   rang.End.Character + 1,
+		}
+		if message != "" {
+			loc.Message = &sarifMessage{Text: message}
+		}
+		return loc
+	}
+
+	rules := make([]sarifRule, 0, len(diagnosticRules))
+	for _, rule := range diagnosticRules {
+		r := sarifRule{ID: rule.Code}
+		r.DefaultConfiguration.Level = sarifLevel(rule.Severity)
+		rules = append(rules, r)
+	}
+
+	sarifResults := make([]sarifResult, 0)
+	for _, result := range results {
+		for _, diag := range result.Diagnostics {
+			res := sarifResult{
+				RuleID:    fmt.Sprint(diag.Code),
+				Level:     sarifLevel(diag.Severity),
+				Message:   sarifMessage{Text: diag.Message},
+				Locations: []sarifLocation{location(result.Path, diag.Range, "")},
+			}
+			for _, related := range diag.RelatedInformation {
+				res.RelatedLocations = append(res.RelatedLocations, location(related.Location.URI.Filename(), related.Location.Range, related.Message))
+			}
+			sarifResults = append(sarifResults, res)
+		}
+	}
+
+	log := map[string]any{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []map[string]any{
+			{
+				"tool": map[string]any{
+					"driver": map[string]any{
+						"name":           "hyprls",
+						"version":        Version,
+						"informationUri": "https://github.com/ewen-lbh/hyprls",
+						"rules":          rules,
+					},
+				},
+				"results": sarifResults,
+			},
+		},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
+
+func sarifLevel(severity protocol.DiagnosticSeverity) string {
+	switch severity {
+	case protocol.DiagnosticSeverityError:
+		return "error"
+	case protocol.DiagnosticSeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// displayPath returns path relative to the working directory if it is inside of it
+func displayPath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	relative, err := filepath.Rel(wd, path)
+	if err != nil || strings.HasPrefix(relative, "..") {
+		return path
+	}
+	return relative
+}
+
+func readFile(path string) (string, error) {
+	contents, err := os.ReadFile(path)
+	return string(contents), err
+}
@@ -0,0 +1,65 @@
+package hyprls
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"go.lsp.dev/protocol"
+)
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "hyprland.conf"), []byte(heredoc.Doc(`
+		source = binds.conf
+		source = missing.conf
+	`)), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(dir, "binds.conf"), []byte(heredoc.Doc(`
+		bind = SUPER, Q, killactive
+		bind = SUPER, q, exit
+	`)), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Check([]string{filepath.Join(dir, "hyprland.conf")}, CheckOptions{Severities: map[string]protocol.DiagnosticSeverity{"bind-conflcit": SeverityOff}})
+	if err == nil || !strings.Contains(err.Error(), `unknown rule "bind-conflcit"`) {
+		t.Errorf("expected an error for an unknown rule, got %v", err)
+	}
+
+	results, err := Check([]string{filepath.Join(dir, "hyprland.conf")}, CheckOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].Path != filepath.Join(dir, "binds.conf") {
+		t.Fatalf("expected results for both files, sorted by path, got %#v", results)
+	}
+	if !HasErrors(results) {
+		t.Errorf("expected the missing source to be an error")
+	}
+
+	var report bytes.Buffer
+	WriteHumanReport(&report, results)
+	if !strings.Contains(report.String(), "binds.conf:2:1: warning: SUPER + q is bound 2 times [bind-conflict]") {
+		t.Errorf("unexpected report:\n%s", report.String())
+	}
+
+	results, _ = Check([]string{filepath.Join(dir, "hyprland.conf")}, CheckOptions{
+		Severities: map[string]protocol.DiagnosticSeverity{
+			"source-not-found": SeverityOff,
+			"bind-conflict":    protocol.DiagnosticSeverityError,
+		},
+	})
+	if len(results[1].Diagnostics) != 0 {
+		t.Errorf("expected source-not-found to be disabled, got %#v", results[1].Diagnostics)
+	}
+	if !HasErrors(results) {
+		t.Errorf("expected bind conflicts to be errors")
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	hyprls "github.com/ewen-lbh/hyprls"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"go.lsp.dev/protocol"
+)
+
+// severitiesFlag is a repeatable rule=severity flag
+type severitiesFlag map[string]protocol.DiagnosticSeverity
+
+func (s severitiesFlag) String() string {
+	return fmt.Sprint(map[string]protocol.DiagnosticSeverity(s))
+}
+
+func (s severitiesFlag) Set(value string) error {
+	rule, level, ok := strings.Cut(value, "=")
+	if !ok {
+		return fmt.Errorf("expected rule=severity, got %q", value)
+	}
+	if !slices.Contains(hyprls.DiagnosticRuleCodes(), rule) {
+		return fmt.Errorf("unknown rule %q, expected one of %s", rule, strings.Join(hyprls.DiagnosticRuleCodes(), ", "))
+	}
+	severity, err := hyprls.ParseSeverity(level)
+	if err != nil {
+		return err
+	}
+	s[rule] = severity
+	return nil
+}
+
+func runCheck(args []string) int {
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	format := flags.String("format", "human", "output format: human, json or sarif")
+	severities := severitiesFlag{}
+	flags.Var(severities, "severity", fmt.Sprintf("override the severity of a rule, as rule=severity (error, warning, info, hint or off). Can be repeated. Rules: %s", strings.Join(hyprls.DiagnosticRuleCodes(), ", ")))
+	hyprlandVersion := flags.String("hyprland-version", "", "Hyprland version to check options against, for files that don't declare one with a \"# hyprlang version\" header")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: hyprls check [flags] [files...]")
+		fmt.Fprintln(flags.Output(), "Checks configuration files and the files they source. Defaults to the main Hyprland configuration file.")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{hyprls.MainConfigPath()}
+	}
+
+	options := hyprls.CheckOptions{Severities: severities}
+	if *hyprlandVersion != "" {
+		version, err := parser_data.ParseVersion(*hyprlandVersion)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "hyprls: %s\n", err)
+			return 2
+		}
+		options.Version = version
+	}
+
+	results, err := hyprls.Check(files, options)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "hyprls: %s\n", err)
+		return 2
+	}
+
+	switch *format {
+	case "human":
+		err = hyprls.WriteHumanReport(os.Stdout, results)
+	case "json":
+		err = hyprls.WriteJSONReport(os.Stdout, results)
+	case "sarif":
+		err = hyprls.WriteSARIFReport(os.Stdout, results)
+	default:
+		fmt.Fprintf(os.Stderr, "hyprls: unknown format %q\n", *format)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "hyprls: while writing report: %s\n", err)
+		return 2
+	}
+
+	if hyprls.HasErrors(results) {
+		return 1
+	}
+	return 0
+}
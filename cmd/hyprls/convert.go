@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	hyprls "github.com/ewen-lbh/hyprls"
+	"go.uber.org/multierr"
+)
+
+func runConvert(args []string) int {
+	flags := flag.NewFlagSet("convert", flag.ExitOnError)
+	from := flags.String("from", "", "format of the input: hyprlang, json, yaml or toml. Guessed from the file's extension by default, hyprlang for standard input")
+	to := flags.String("to", "", "format of the output: hyprlang, json, yaml or toml. Defaults to hyprlang when converting from another format")
+	includeDefaults := flags.Bool("defaults", false, "also write options that are set to their default value")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: hyprls convert [flags] [file]")
+		fmt.Fprintln(flags.Output(), "Converts a configuration between Hyprlang and JSON, YAML or TOML. Reads from standard input when no file is given, or when the file is -.")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	path := "-"
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return 2
+	} else if flags.NArg() == 1 {
+		path = flags.Arg(0)
+	}
+
+	options := hyprls.ConvertOptions{From: hyprls.FormatHyprlang, IncludeDefaults: *includeDefaults}
+	if format, ok := hyprls.DataFormatOfPath(path); ok {
+		options.From = format
+	}
+	if *from != "" {
+		format, err := hyprls.ParseDataFormat(*from)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "hyprls: %s\n", err)
+			return 2
+		}
+		options.From = format
+	}
+
+	switch {
+	case *to != "":
+		format, err := hyprls.ParseDataFormat(*to)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "hyprls: %s\n", err)
+			return 2
+		}
+		options.To = format
+	case options.From != hyprls.FormatHyprlang:
+		options.To = hyprls.FormatHyprlang
+	default:
+		fmt.Fprintln(os.Stderr, "hyprls: --to is required when converting from hyprlang")
+		return 2
+	}
+
+	input, err := readInput(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "hyprls: while reading %s: %s\n", path, err)
+		return 2
+	}
+
+	output, skipped, err := hyprls.Convert(input, options)
+	for _, line := range multierr.Errors(skipped) {
+		fmt.Fprintf(os.Stderr, "hyprls: warning: %s, so it is left out of the output\n", line)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "hyprls: cannot convert %s from %s to %s:\n", path, options.From, options.To)
+		for _, err := range multierr.Errors(err) {
+			fmt.Fprintf(os.Stderr, "  %s\n", err)
+		}
+		return 1
+	}
+	fmt.Print(output)
+	return 0
+}
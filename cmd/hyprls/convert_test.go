@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// run runs a subcommand, and returns its exit code and what it wrote to the standard output and error
+func run(t *testing.T, command func([]string) int, args ...string) (code int, stdout string, stderr string) {
+	t.Helper()
+	dir := t.TempDir()
+	outFile, err := os.Create(filepath.Join(dir, "stdout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	errFile, err := os.Create(filepath.Join(dir, "stderr"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer outFile.Close()
+	defer errFile.Close()
+
+	originalStdout, originalStderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = outFile, errFile
+	code = command(args)
+	os.Stdout, os.Stderr = originalStdout, originalStderr
+
+	out, err := os.ReadFile(outFile.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := os.ReadFile(errFile.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code, string(out), string(errs)
+}
+
+func TestConvertFixture(t *testing.T) {
+	code, stdout, stderr := run(t, runConvert, "--to", "json", "../../parser/fixtures/test.hl")
+	if code != 0 {
+		t.Fatalf("exited with code %d:\n%s", code, stderr)
+	}
+
+	var converted map[string]any
+	if err := json.Unmarshal([]byte(stdout), &converted); err != nil {
+		t.Fatalf("output is not JSON: %s\n%s", err, stdout)
+	}
+	input, _ := converted["input"].(map[string]any)
+	if touchpad, _ := input["touchpad"].(map[string]any); touchpad["natural_scroll"] != true {
+		t.Errorf("expected input:touchpad to be converted, got %v", input)
+	}
+
+	if !strings.Contains(stderr, "line 22: source: cannot be represented in a Configuration, so it is left out of the output") {
+		t.Errorf("expected a warning about the source statement, got:\n%s", stderr)
+	}
+}
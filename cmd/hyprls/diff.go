@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	hyprls "github.com/ewen-lbh/hyprls"
+)
+
+func runDiff(args []string) int {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "print the changes as JSON")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: hyprls diff [flags] old.conf new.conf")
+		fmt.Fprintln(flags.Output(), "Compares two configurations after following source statements and expanding variables. Exits with 1 if they differ.")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return 2
+	}
+
+	changes, err := hyprls.Diff(flags.Arg(0), flags.Arg(1))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "hyprls: %s\n", err)
+		return 2
+	}
+
+	if *asJSON {
+		err = hyprls.WriteJSONDiff(os.Stdout, changes)
+	} else {
+		err = hyprls.WriteHumanDiff(os.Stdout, changes)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "hyprls: while writing changes: %s\n", err)
+		return 2
+	}
+
+	if len(changes) > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	hyprls "github.com/ewen-lbh/hyprls"
+)
+
+func runDoc(args []string) int {
+	flags := flag.NewFlagSet("doc", flag.ExitOnError)
+	search := flags.String("search", "", "search variables, keywords and dispatchers whose name or description match the query")
+	limit := flags.Int("limit", 10, "maximum number of search results, 0 for no limit")
+	asJSON := flags.Bool("json", false, "output documentation as JSON")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: hyprls doc [flags] [names...]")
+		fmt.Fprintln(flags.Output(), "Shows the documentation of variables (e.g. decoration:blur:size), sections (e.g. decoration:blur), keywords (e.g. bind) or dispatchers (e.g. dispatcher:movefocus).")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if *search == "" && flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	docs := make([]hyprls.Doc, 0)
+	if *search != "" {
+		docs = hyprls.SearchDocs(*search)
+		if *limit > 0 && len(docs) > *limit {
+			docs = docs[:*limit]
+		}
+	}
+
+	status := 0
+	for _, name := range flags.Args() {
+		doc, found := hyprls.LookupDoc(name)
+		if !found {
+			fmt.Fprintf(os.Stderr, "hyprls: no documentation for %q%s\n", name, suggestions(name))
+			status = 1
+			continue
+		}
+		docs = append(docs, doc)
+	}
+
+	if *asJSON {
+		if err := hyprls.WriteDocsJSON(os.Stdout, docs); err != nil {
+			fmt.Fprintf(os.Stderr, "hyprls: %s\n", err)
+			return 2
+		}
+		return status
+	}
+
+	if *search != "" && len(docs) == 0 {
+		fmt.Fprintf(os.Stderr, "hyprls: nothing matches %q\n", *search)
+		return 1
+	}
+	for i, doc := range docs {
+		if i > 0 {
+			fmt.Println()
+		}
+		if err := hyprls.WriteDoc(os.Stdout, doc); err != nil {
+			fmt.Fprintf(os.Stderr, "hyprls: %s\n", err)
+			return 2
+		}
+	}
+	return status
+}
+
+// suggestions returns a "did you mean" hint with the best search results for name, or an empty string if there are none
+func suggestions(name string) string {
+	results := hyprls.SearchDocs(name)
+	if len(results) == 0 {
+		return ""
+	}
+	paths := make([]string, 0, 3)
+	for _, result := range results[:min(3, len(results))] {
+		paths = append(paths, result.Path)
+	}
+	return fmt.Sprintf(", did you mean %s?", strings.Join(paths, ", "))
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	hyprls "github.com/ewen-lbh/hyprls"
+	"github.com/ewen-lbh/hyprls/parser"
+)
+
+func runEffective(args []string) int {
+	flags := flag.NewFlagSet("effective", flag.ExitOnError)
+	config := flags.String("config", hyprls.MainConfigPath(), "main configuration file")
+	all := flags.Bool("all", false, "also show options that the configuration does not set")
+	asJSON := flags.Bool("json", false, "print the options as JSON")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: hyprls effective [flags] [options or sections...]")
+		fmt.Fprintln(flags.Output(), "Shows the value every option ends up with once the configuration and the files it sources are evaluated, where it was set, and the values it overrides.")
+		fmt.Fprintln(flags.Output(), "Only shows the given options and sections, e.g. general:gaps_out or decoration, if any are given.")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	options, err := hyprls.EffectiveConfig(*config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "hyprls: %s\n", err)
+		return 2
+	}
+
+	if flags.NArg() > 0 {
+		options = hyprls.FilterEffectiveOptions(options, flags.Args())
+		if len(options) == 0 {
+			fmt.Fprintf(os.Stderr, "hyprls: no options match %v, see hyprls doc --search\n", flags.Args())
+			return 1
+		}
+	} else if !*all {
+		set := make([]parser.EffectiveOption, 0)
+		for _, option := range options {
+			if option.SetBy != nil {
+				set = append(set, option)
+			}
+		}
+		options = set
+	}
+
+	if *asJSON {
+		err = hyprls.WriteJSONEffectiveConfig(os.Stdout, options)
+	} else {
+		err = hyprls.WriteHumanEffectiveConfig(os.Stdout, options)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "hyprls: while writing options: %s\n", err)
+		return 2
+	}
+	return 0
+}
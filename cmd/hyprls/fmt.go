@@ -0,0 +1,123 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ewen-lbh/hyprls/internal/textdiff"
+	"github.com/ewen-lbh/hyprls/parser"
+)
+
+func runFmt(args []string) int {
+	flags := flag.NewFlagSet("fmt", flag.ExitOnError)
+	write := flags.Bool("w", false, "write the result to the files instead of standard output")
+	check := flags.Bool("check", false, "don't write anything, list files that are not formatted and exit with status 1 if there are any")
+	diff := flags.Bool("diff", false, "print a unified diff of the changes instead of the formatted files")
+	recursive := flags.Bool("r", false, "also format every file included through source statements")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: hyprls fmt [flags] [files...]")
+		fmt.Fprintln(flags.Output(), "Formats configuration files. Reads from standard input when no files are given, or when a file is -.")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	if *recursive {
+		var err error
+		files, err = withSourcedFiles(files)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "hyprls: %s\n", err)
+			return 2
+		}
+	}
+
+	unformatted := false
+	for _, path := range files {
+		if path == "-" && *write {
+			fmt.Fprintln(os.Stderr, "hyprls: cannot use -w when reading from standard input")
+			return 2
+		}
+
+		contents, err := readInput(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "hyprls: while reading %s: %s\n", path, err)
+			return 2
+		}
+
+		formatted := parser.Format(contents, parser.FormatOptions{})
+		if formatted != contents {
+			unformatted = true
+		}
+
+		switch {
+		case *diff:
+			name := path
+			if path == "-" {
+				name = "<standard input>"
+			}
+			fmt.Print(textdiff.Unified(name+" (original)", name+" (formatted)", contents, formatted))
+		case *check:
+			if formatted != contents {
+				fmt.Println(path)
+			}
+		case !*write:
+			fmt.Print(formatted)
+		}
+
+		if *write && !*check && formatted != contents {
+			if err := os.WriteFile(path, []byte(formatted), 0o644); err != nil {
+				fmt.Fprintf(os.Stderr, "hyprls: while writing %s: %s\n", path, err)
+				return 2
+			}
+		}
+	}
+
+	if *check && unformatted {
+		return 1
+	}
+	return 0
+}
+
+// withSourcedFiles returns files along with every file they include through source statements, without duplicates
+func withSourcedFiles(files []string) ([]string, error) {
+	all := make([]string, 0, len(files))
+	seen := make(map[string]bool)
+	for _, path := range files {
+		if path == "-" {
+			return nil, fmt.Errorf("cannot follow source statements when reading from standard input")
+		}
+		graph, err := parser.LoadGraph(path, func(path string) (string, error) {
+			contents, err := os.ReadFile(path)
+			return string(contents), err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("while loading %s: %w", path, err)
+		}
+		for _, sourceErr := range graph.Errors {
+			fmt.Fprintf(os.Stderr, "hyprls: %s\n", sourceErr)
+		}
+		for _, file := range graph.SortedFiles() {
+			if seen[file.Path] {
+				continue
+			}
+			seen[file.Path] = true
+			all = append(all, file.Path)
+		}
+	}
+	return all, nil
+}
+
+func readInput(path string) (string, error) {
+	if path == "-" {
+		contents, err := io.ReadAll(os.Stdin)
+		return string(contents), err
+	}
+	contents, err := os.ReadFile(path)
+	return string(contents), err
+}
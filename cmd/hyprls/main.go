@@ -3,29 +3,64 @@ package main
 import (
 	"fmt"
 	"os"
-	"path/filepath"
-
-	hyprls "github.com/ewen-lbh/hyprls"
-	"go.uber.org/zap"
+	"strings"
 )
 
-var OutputServerLogs string
+type command struct {
+	Name        string
+	Description string
+	Run         func(args []string) int
+}
+
+var commands = []command{
+	{"serve", "start the language server (default)", runServe},
+	{"check", "report problems in configuration files", runCheck},
+	{"fmt", "format configuration files", runFmt},
+	{"diff", "compare the effective settings of two configurations", runDiff},
+	{"effective", "show the value every option ends up with, and where it was set", runEffective},
+	{"migrate", "replace renamed options and legacy statements", runMigrate},
+	{"convert", "convert configurations to and from JSON, YAML or TOML", runConvert},
+	{"doc", "show documentation of options and keywords", runDoc},
+	{"schema", "print a JSON Schema or catalog of all options and keywords", runSchema},
+	{"version", "print the version of hyprls", runVersion},
+}
 
 func main() {
-	logconf := zap.NewDevelopmentConfig()
-	if OutputServerLogs != "" {
-		logconf.OutputPaths = []string{OutputServerLogs, "stderr"}
+	args := os.Args[1:]
+	// hyprls and hyprls --some-flag start the server, since that's how editors launch it
+	if len(args) == 0 || (strings.HasPrefix(args[0], "-") && !isHelpFlag(args[0]) && args[0] != "--version") {
+		os.Exit(runServe(args))
 	}
-	logger, err := logconf.Build()
-	if err != nil {
-		fmt.Printf("while building logger: %s", err)
-		os.Exit(1)
+
+	switch {
+	case isHelpFlag(args[0]) || args[0] == "help":
+		usage()
+		os.Exit(0)
+	case args[0] == "--version":
+		os.Exit(runVersion(nil))
 	}
 
-	logger.Debug("going to start server")
-	if OutputServerLogs != "" {
-		hyprls.StartServer(logger, filepath.Dir(OutputServerLogs))
-	} else {
-		hyprls.StartServer(logger, "")
+	for _, cmd := range commands {
+		if cmd.Name == args[0] {
+			os.Exit(cmd.Run(args[1:]))
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "hyprls: unknown command %q\n\n", args[0])
+	usage()
+	os.Exit(2)
+}
+
+func isHelpFlag(arg string) bool {
+	return arg == "-h" || arg == "-help" || arg == "--help"
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: hyprls [command] [flags]")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.Name, cmd.Description)
 	}
+	fmt.Fprintf(os.Stderr, "  %-10s %s\n", "help", "show this help")
+	fmt.Fprintln(os.Stderr, "\nRun hyprls [command] --help for the flags of a command.")
 }
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	hyprls "github.com/ewen-lbh/hyprls"
+	"github.com/ewen-lbh/hyprls/internal/textdiff"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+)
+
+func runMigrate(args []string) int {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	to := flags.String("to", "", "Hyprland version to migrate to, defaults to the latest known one")
+	write := flags.Bool("w", false, "write the migrated files instead of printing a unified diff of the changes")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: hyprls migrate [flags] [file]")
+		fmt.Fprintln(flags.Output(), "Replaces renamed options and legacy statements in a configuration and the files it sources, keeping comments. Defaults to the main Hyprland configuration file.")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return 2
+	}
+	path := hyprls.MainConfigPath()
+	if flags.NArg() == 1 {
+		path = flags.Arg(0)
+	}
+
+	version := ""
+	if *to != "" {
+		var err error
+		version, err = parser_data.ParseVersion(*to)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "hyprls: %s\n", err)
+			return 2
+		}
+	}
+
+	migrated, err := hyprls.Migrate(path, version)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "hyprls: %s\n", err)
+		return 2
+	}
+
+	for _, file := range migrated {
+		for _, deprecation := range file.Manual {
+			fmt.Fprintf(os.Stderr, "hyprls: warning: %s:%d: %s\n", file.Path, deprecation.Range.Start.Line+1, deprecation.Message())
+		}
+		if file.After == file.Before {
+			continue
+		}
+		if !*write {
+			fmt.Print(textdiff.Unified(file.Path+" (original)", file.Path+" (migrated)", file.Before, file.After))
+			continue
+		}
+		if err := os.WriteFile(file.Path, []byte(file.After), 0o644); err != nil {
+			fmt.Fprintf(os.Stderr, "hyprls: while writing %s: %s\n", file.Path, err)
+			return 2
+		}
+		fmt.Fprintf(os.Stderr, "migrated %s\n", file.Path)
+	}
+	return 0
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ewen-lbh/hyprls/parser"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+)
+
+func runSchema(args []string) int {
+	flags := flag.NewFlagSet("schema", flag.ExitOnError)
+	format := flags.String("format", "jsonschema", "output format: jsonschema, for a JSON Schema of the configuration, or catalog, for the list of every section, option and keyword")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: hyprls schema [flags]")
+		fmt.Fprintln(flags.Output(), "Prints a machine-readable description of the configuration, for use by other tools.")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	var output any
+	switch *format {
+	case "jsonschema":
+		output = parser.JSONSchema()
+	case "catalog":
+		output = parser_data.BuildCatalog()
+	default:
+		fmt.Fprintf(os.Stderr, "hyprls: unknown format %q\n", *format)
+		return 2
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(output); err != nil {
+		fmt.Fprintf(os.Stderr, "hyprls: %s\n", err)
+		return 2
+	}
+	return 0
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	hyprls "github.com/ewen-lbh/hyprls"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func runServe(args []string) int {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	logFile := flags.String("log-file", "", "also write server logs to this file")
+	logLevel := flags.String("log-level", "info", "minimum level of server logs: debug, info, warn or error")
+	traceRPC := flags.String("trace-rpc", "", "write every request and response exchanged with the client to files in this directory")
+	docsDir := flags.String("docs-dir", "", "load the documentation of options and keywords from this checkout of hyprland-wiki instead of the one compiled into hyprls")
+	listen := flags.String("listen", "", "accept client connections on tcp:host:port or unix:/path/to/socket instead of using stdin and stdout")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: hyprls serve [flags]")
+		fmt.Fprintln(flags.Output(), "Starts the language server, communicating over stdin and stdout, or over a socket with --listen.")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	level, err := zapcore.ParseLevel(*logLevel)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "hyprls: invalid log level: %s\n", err)
+		return 2
+	}
+
+	logconf := zap.NewDevelopmentConfig()
+	logconf.Level = zap.NewAtomicLevelAt(level)
+	if *logFile != "" {
+		logconf.OutputPaths = []string{*logFile, "stderr"}
+	}
+	logger, err := logconf.Build()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "hyprls: while building logger: %s\n", err)
+		return 1
+	}
+
+	if *docsDir != "" {
+		if err := hyprls.LoadDocumentation(*docsDir); err != nil {
+			logger.Warn("falling back to the documentation compiled into hyprls", zap.Error(err))
+		}
+	}
+
+	if *traceRPC != "" {
+		if err := os.MkdirAll(*traceRPC, 0755); err != nil {
+			fmt.Fprintf(os.Stderr, "hyprls: while creating RPC trace directory: %s\n", err)
+			return 1
+		}
+	}
+
+	if *listen == "" {
+		logger.Debug("going to start server")
+		hyprls.StartServer(logger, *traceRPC)
+		return 0
+	}
+
+	listener, err := hyprls.Listen(*listen)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "hyprls: while listening: %s\n", err)
+		return 1
+	}
+	logger.Info("listening for clients", zap.Stringer("address", listener.Addr()))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := hyprls.Serve(ctx, listener, logger, *traceRPC); err != nil {
+		fmt.Fprintf(os.Stderr, "hyprls: %s\n", err)
+		return 1
+	}
+	return 0
+}
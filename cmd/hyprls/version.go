@@ -0,0 +1,12 @@
+package main
+
+import (
+	"fmt"
+
+	hyprls "github.com/ewen-lbh/hyprls"
+)
+
+func runVersion(args []string) int {
+	fmt.Printf("hyprls %s\n", hyprls.Version)
+	return 0
+}
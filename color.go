@@ -13,20 +13,21 @@ import (
 )
 
 func (h Handler) ColorPresentation(ctx context.Context, params *protocol.ColorPresentationParams) ([]protocol.ColorPresentation, error) {
-	logger.Debug("LSP:ColorPresentation", zap.Any("color", params.Color), zap.Any("range", params.Range))
+	literal := encodeColorLiteral(params.Color)
+	h.Logger.Debug("LSP:ColorPresentation", zap.Any("color", params.Color), zap.Any("range", params.Range), zap.String("literal", literal))
 	return []protocol.ColorPresentation{
 		{
-			Label: encodeColorLiteral(params.Color),
+			Label: literal,
 			TextEdit: &protocol.TextEdit{
 				Range:   params.Range,
-				NewText: encodeColorLiteral(params.Color),
+				NewText: literal,
 			},
 		},
 	}, nil
 }
 
 func (h Handler) DocumentColor(ctx context.Context, params *protocol.DocumentColorParams) ([]protocol.ColorInformation, error) {
-	document, err := parse(params.TextDocument.URI)
+	document, err := h.state.parse(params.TextDocument.URI)
 	if err != nil {
 		return []protocol.ColorInformation{}, fmt.Errorf("while parsing: %w", err)
 	}
@@ -55,14 +56,11 @@ func (h Handler) DocumentColor(ctx context.Context, params *protocol.DocumentCol
 }
 
 func decodeColorLiteral(raw string) protocol.Color {
-	logger.Debug("decodeColorLiteral", zap.String("raw", raw))
 	color, err := parser.ParseColor(raw)
 	if err != nil {
 		return protocol.Color{}
 	}
 
-	logger.Debug("decodeColorLiteral", zap.Any("color", color))
-
 	return protocol.Color{
 		Red:   roundToThree(float64(color.R) / 255.0),
 		Alpha: roundToThree(float64(color.A) / 255.0),
@@ -72,7 +70,6 @@ func decodeColorLiteral(raw string) protocol.Color {
 }
 
 func encodeColorLiteral(color protocol.Color) string {
-	logger.Debug("encodeColorLiteral", zap.Any("color", color))
 	out := strings.TrimPrefix(csscolorparser.Color{
 		R: roundToThree(color.Red),
 		G: roundToThree(color.Green),
@@ -85,8 +82,6 @@ func encodeColorLiteral(color protocol.Color) string {
 	} else {
 		out = fmt.Sprintf("rgba(%s)", out)
 	}
-
-	logger.Debug("encodeColorLiteral", zap.String("out", out))
 	return out
 }
 
@@ -5,15 +5,8 @@ import (
 	"testing"
 
 	"go.lsp.dev/protocol"
-	"go.uber.org/zap"
 )
 
-func init() {
-	if logger == nil {
-		logger, _ = zap.NewDevelopmentConfig().Build()
-	}
-}
-
 func TestColorEncoding(t *testing.T) {
 	for i := 0; i < 20; i++ {
 		color := randomColor()
@@ -44,14 +37,11 @@ func TestColorDecoding(t *testing.T) {
 
 	for i := 0; i < 20; i++ {
 		color := randomColor()
-		originalLogger := *logger
-		logger = logger.WithOptions(zap.Fields(zap.Int("test color number", i)))
 		encoded := encodeColorLiteral(color)
 		decoded := decodeColorLiteral(encoded)
 		if !compareColorStructs(color, decoded) {
 			t.Errorf("Color %d: %#v != %#v (was encoded to %s)", i, color, decoded, encoded)
 		}
-		logger = &originalLogger
 	}
 }
 
@@ -0,0 +1,93 @@
+package hyprls
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+)
+
+// CommandDiff compares two configurations, see Diff. Its arguments are the URIs or paths of the main files of the old and new configurations, and it returns a list of parser.Change.
+// Files opened in the editor are compared with their unsaved contents.
+const CommandDiff = "hyprls.diff"
+
+// CommandShowEffectiveConfig evaluates a configuration, see parser.Graph.EffectiveOptions. Its optional argument is the URI or path of one of the configuration's files, and defaults to the main Hyprland configuration file.
+// It returns every option as a list of parser.EffectiveOption.
+const CommandShowEffectiveConfig = "hyprls.showEffectiveConfig"
+
+// commands are the commands clients can run with workspace/executeCommand, by name
+var commands = map[string]func(h Handler, arguments []interface{}) (interface{}, error){
+	CommandDiff:                Handler.diffCommand,
+	CommandShowEffectiveConfig: Handler.showEffectiveConfigCommand,
+}
+
+func commandNames() []string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (h Handler) ExecuteCommand(ctx context.Context, params *protocol.ExecuteCommandParams) (interface{}, error) {
+	command, ok := commands[params.Command]
+	if !ok {
+		return nil, fmt.Errorf("unknown command %q", params.Command)
+	}
+	return command(h, params.Arguments)
+}
+
+func (h Handler) diffCommand(arguments []interface{}) (interface{}, error) {
+	paths, err := pathArguments(arguments, "old configuration", "new configuration")
+	if err != nil {
+		return nil, err
+	}
+	return diffConfigurations(paths[0], paths[1], h.state.readConfigFile)
+}
+
+func (h Handler) showEffectiveConfigCommand(arguments []interface{}) (interface{}, error) {
+	path := h.state.mainConfig
+	if len(arguments) > 0 {
+		paths, err := pathArguments(arguments, "file")
+		if err != nil {
+			return nil, err
+		}
+		path = paths[0]
+	}
+
+	graph, err := h.state.configGraph(uri.File(path))
+	if err != nil {
+		return nil, err
+	}
+	// Problems are reported by diagnostics
+	options, _ := graph.EffectiveOptions()
+	return options, nil
+}
+
+// pathArguments returns the paths given as arguments to a command, as file URIs or paths. names are the names of the expected arguments, used in error messages.
+func pathArguments(arguments []interface{}, names ...string) ([]string, error) {
+	if len(arguments) != len(names) {
+		return nil, fmt.Errorf("expected %d arguments (%s), got %d", len(names), strings.Join(names, ", "), len(arguments))
+	}
+	paths := make([]string, 0, len(arguments))
+	for i, argument := range arguments {
+		raw, ok := argument.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: expected a URI or a path, got %v", names[i], argument)
+		}
+		if !strings.Contains(raw, "://") {
+			paths = append(paths, raw)
+			continue
+		}
+		if parsed, err := url.ParseRequestURI(raw); err != nil || parsed.Scheme != uri.FileScheme {
+			return nil, fmt.Errorf("%s: expected a file URI, got %s", names[i], raw)
+		}
+		paths = append(paths, uri.URI(raw).Filename())
+	}
+	return paths, nil
+}
@@ -14,12 +14,12 @@ import (
 )
 
 func (h Handler) Completion(ctx context.Context, params *protocol.CompletionParams) (*protocol.CompletionList, error) {
-	line, err := currentLine(params.TextDocument.URI, params.Position)
+	line, err := h.state.currentLine(params.TextDocument.URI, params.Position)
 	if err != nil {
 		return nil, nil
 	}
 
-	file, err := parse(params.TextDocument.URI)
+	file, err := h.state.parse(params.TextDocument.URI)
 	if err != nil {
 		return nil, nil
 	}
@@ -33,16 +33,28 @@ func (h Handler) Completion(ctx context.Context, params *protocol.CompletionPara
 
 	// we are after the equals sign, suggest custom properties only
 	if cursorIsAfterEquals {
+		if submaps := submapCompletions(file, line, params.Position); len(submaps) > 0 {
+			return &protocol.CompletionList{
+				Items: submaps,
+			}, nil
+		}
+		if dispatchers := dispatcherCompletions(file, line, params.Position); len(dispatchers) > 0 {
+			return &protocol.CompletionList{
+				Items: dispatchers,
+			}, nil
+		}
+
 		items := make([]protocol.CompletionItem, 0)
 
-		cursorOrLineEnd := min(int(params.Position.Character), len(line)-1)
-		characterBeforeCursorIsDollarSign := line[cursorOrLineEnd] == '$'
+		// The cursor is after the equals sign, so there is at least one character before it
+		characterBeforeCursor := line[min(int(params.Position.Character), len(line))-1]
+		characterBeforeCursorIsDollarSign := characterBeforeCursor == '$'
 
 		// Don't propose custom variables if in the middle of typing a word
 		// Only propose if a dollar sign was typed or is just before the cursor
 		// Or we are after whitespace
 		// Or we are in the middle of a color completion (typed a r, and key is a color or gradient)
-		if !characterBeforeCursorIsDollarSign && !unicode.IsSpace(rune(line[params.Position.Character-1])) {
+		if !characterBeforeCursorIsDollarSign && !unicode.IsSpace(rune(characterBeforeCursor)) {
 			return nil, nil
 		}
 
@@ -50,8 +62,8 @@ func (h Handler) Completion(ctx context.Context, params *protocol.CompletionPara
 		var textEditRange protocol.Range
 		if characterBeforeCursorIsDollarSign {
 			textEditRange = protocol.Range{
-				protocol.Position{params.Position.Line, params.Position.Character - 1},
-				protocol.Position{params.Position.Line, params.Position.Character},
+				Start: protocol.Position{Line: params.Position.Line, Character: params.Position.Character - 1},
+				End:   protocol.Position{Line: params.Position.Line, Character: params.Position.Character},
 			}
 		} else {
 			textEditRange = collapsedRange(params.Position)
@@ -87,10 +99,16 @@ func (h Handler) Completion(ctx context.Context, params *protocol.CompletionPara
 				if err != nil {
 					valueKind = parser.String
 				}
+				for _, value := range assignment.Enum {
+					items = append(items, protocol.CompletionItem{
+						Label: value,
+						Kind:  protocol.CompletionItemKindEnumMember,
+					})
+				}
 			}
 		}
 
-		logger.Debug("LSP:Completion", zap.Any("valueKind", valueKind))
+		h.Logger.Debug("LSP:Completion", zap.Any("valueKind", valueKind))
 
 		switch valueKind {
 		case parser.Color, parser.Gradient:
@@ -151,9 +169,13 @@ func (h Handler) Completion(ctx context.Context, params *protocol.CompletionPara
 		}
 	}
 
+	version := h.state.targetVersion(params.TextDocument.URI)
 	items := make([]protocol.CompletionItem, 0)
 vars:
 	for _, vardef := range availableVariables {
+		if !vardef.AvailableIn(version) {
+			continue
+		}
 		// Don't suggest variables that are already defined
 		for _, definedvar := range sec.Assignments {
 			if vardef.Name == definedvar.Key {
@@ -206,6 +228,42 @@ subsections:
 	}, nil
 }
 
+// submapCompletions suggests submap names when the cursor is on the parameter of a bind that uses the submap dispatcher
+func submapCompletions(document parser.Section, line string, position protocol.Position) []protocol.CompletionItem {
+	argument, ok := bindArgumentAt(document, line, position)
+	if !ok || argument.Name != "params" || argument.Bind.Dispatcher != "submap" {
+		return nil
+	}
+
+	// Replace what was already typed of the submap name
+	editRange := protocol.Range{
+		Start: protocol.Position{Line: position.Line, Character: uint32(min(argument.Start, int(position.Character)))},
+		End:   position,
+	}
+
+	items := make([]protocol.CompletionItem, 0)
+	suggested := make(map[string]bool)
+	for _, submap := range document.Submaps() {
+		if suggested[submap.Name] {
+			continue
+		}
+		suggested[submap.Name] = true
+		items = append(items, protocol.CompletionItem{
+			Label:    submap.Name,
+			Kind:     protocol.CompletionItemKindModule,
+			Detail:   fmt.Sprintf("submap declared on line %d", submap.Opening.Position.Line+1),
+			TextEdit: &protocol.TextEdit{Range: editRange, NewText: submap.Name},
+		})
+	}
+	items = append(items, protocol.CompletionItem{
+		Label:    parser.SubmapReset,
+		Kind:     protocol.CompletionItemKindKeyword,
+		Detail:   "go back to the global submap",
+		TextEdit: &protocol.TextEdit{Range: editRange, NewText: parser.SubmapReset},
+	})
+	return items
+}
+
 func (h Handler) CompletionResolve(ctx context.Context, params *protocol.CompletionItem) (*protocol.CompletionItem, error) {
 	return nil, errors.New("unimplemented")
 }
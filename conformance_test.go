@@ -0,0 +1,284 @@
+package hyprls
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/ewen-lbh/hyprls/internal/golden"
+	"go.lsp.dev/jsonrpc2"
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+	"go.uber.org/zap"
+)
+
+// conformanceConfigDir is the directory of the Hyprland configuration during conformance tests. It does not exist: documents are only known to the server through the client.
+const conformanceConfigDir = "/conformance/.config/hypr"
+
+// lspSession is a client connected to a server that runs in the test, through an in-memory pipe.
+// Every request and notification it sends is recorded in a transcript, along with what the server answers.
+// At the end of the test, the transcript is compared to the golden file fixtures/conformance/<name of the test>.json,
+// run the tests with -update to rewrite it.
+type lspSession struct {
+	t           *testing.T
+	ctx         context.Context
+	conn        jsonrpc2.Conn
+	diagnostics chan protocol.PublishDiagnosticsParams
+	transcript  []transcriptStep
+}
+
+type transcriptStep struct {
+	Method string `json:"method"`
+	Params any    `json:"params,omitempty"`
+	// Result is the response to a request, or null
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+	// Diagnostics are published by the server after the document was opened or changed
+	Diagnostics *[]protocol.Diagnostic `json:"diagnostics,omitempty"`
+}
+
+// newLSPSession starts a server and connects to it. The session is initialized, with settings as the initialization options if they are not nil.
+func newLSPSession(t *testing.T, settings map[string]any) *lspSession {
+	t.Helper()
+	// The main configuration file is looked up in the configuration directory, which must not be the one of whoever runs the tests
+	t.Setenv("HOME", "/conformance")
+	t.Setenv("XDG_CONFIG_HOME", filepath.Dir(conformanceConfigDir))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	serverSide, clientSide := net.Pipe()
+	served := make(chan error)
+	go func() {
+		served <- ServeConnection(ctx, serverSide, zap.NewNop())
+	}()
+
+	session := &lspSession{
+		t:           t,
+		ctx:         ctx,
+		conn:        jsonrpc2.NewConn(jsonrpc2.NewStream(clientSide)),
+		diagnostics: make(chan protocol.PublishDiagnosticsParams, 16),
+	}
+	session.conn.Go(ctx, func(ctx context.Context, reply jsonrpc2.Replier, req jsonrpc2.Request) error {
+		if req.Method() == protocol.MethodTextDocumentPublishDiagnostics {
+			var params protocol.PublishDiagnosticsParams
+			if err := json.Unmarshal(req.Params(), &params); err == nil {
+				session.diagnostics <- params
+			}
+		}
+		return reply(ctx, nil, nil)
+	})
+	t.Cleanup(func() {
+		session.conn.Close()
+		<-session.conn.Done()
+		cancel()
+		if err := <-served; err != nil {
+			t.Errorf("the server stopped with an error: %s", err)
+		}
+	})
+
+	var initialized protocol.InitializeResult
+	session.call(protocol.MethodInitialize, &protocol.InitializeParams{InitializationOptions: settings}, &initialized)
+	// The version of the server changes with every build
+	if initialized.ServerInfo != nil {
+		initialized.ServerInfo.Version = "(version)"
+		session.transcript[len(session.transcript)-1].Result, _ = json.Marshal(initialized)
+	}
+	session.notify(protocol.MethodInitialized, &protocol.InitializedParams{})
+	return session
+}
+
+// documentURI returns the URI of the file named name in the configuration directory
+func documentURI(name string) protocol.DocumentURI {
+	return uri.File(filepath.Join(conformanceConfigDir, name))
+}
+
+// call sends a request, records it and its response, and decodes the response into result if it is not nil
+func (s *lspSession) call(method string, params any, result any) {
+	s.t.Helper()
+	var raw json.RawMessage
+	step := transcriptStep{Method: method, Params: params}
+	if _, err := s.conn.Call(s.ctx, method, params, &raw); err != nil {
+		step.Error = err.Error()
+	} else {
+		step.Result = raw
+		if result != nil {
+			if err := json.Unmarshal(raw, result); err != nil {
+				s.t.Fatalf("while decoding the response to %s: %s", method, err)
+			}
+		}
+	}
+	s.transcript = append(s.transcript, step)
+}
+
+func (s *lspSession) notify(method string, params any) {
+	s.t.Helper()
+	if err := s.conn.Notify(s.ctx, method, params); err != nil {
+		s.t.Fatalf("while sending %s: %s", method, err)
+	}
+	s.transcript = append(s.transcript, transcriptStep{Method: method, Params: params})
+}
+
+// open opens the document named name, with contents, and records the diagnostics the server publishes for it
+func (s *lspSession) open(name string, contents string) []protocol.Diagnostic {
+	s.t.Helper()
+	s.notify(protocol.MethodTextDocumentDidOpen, &protocol.DidOpenTextDocumentParams{
+		TextDocument: protocol.TextDocumentItem{URI: documentURI(name), LanguageID: "hyprlang", Version: 1, Text: contents},
+	})
+	return s.awaitDiagnostics(documentURI(name))
+}
+
+// change replaces the contents of the document named name, and records the diagnostics the server publishes for it
+func (s *lspSession) change(name string, version int32, contents string) []protocol.Diagnostic {
+	s.t.Helper()
+	s.notify(protocol.MethodTextDocumentDidChange, map[string]any{
+		"textDocument": protocol.VersionedTextDocumentIdentifier{TextDocumentIdentifier: document(name), Version: version},
+		// The server asks for full synchronization, so changes have no range. protocol.TextDocumentContentChangeEvent always has one.
+		"contentChanges": []map[string]string{{"text": contents}},
+	})
+	return s.awaitDiagnostics(documentURI(name))
+}
+
+func (s *lspSession) awaitDiagnostics(document protocol.DocumentURI) []protocol.Diagnostic {
+	s.t.Helper()
+	for {
+		select {
+		case published := <-s.diagnostics:
+			if published.URI != document {
+				continue
+			}
+			diagnostics := append([]protocol.Diagnostic{}, published.Diagnostics...)
+			s.transcript[len(s.transcript)-1].Diagnostics = &diagnostics
+			return diagnostics
+		case <-s.ctx.Done():
+			s.t.Fatalf("the server did not publish diagnostics for %s", document)
+			return nil
+		}
+	}
+}
+
+// position returns the text document position params of the document named name, at line and character
+func position(name string, line, character uint32) protocol.TextDocumentPositionParams {
+	return protocol.TextDocumentPositionParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: documentURI(name)},
+		Position:     protocol.Position{Line: line, Character: character},
+	}
+}
+
+func document(name string) protocol.TextDocumentIdentifier {
+	return protocol.TextDocumentIdentifier{URI: documentURI(name)}
+}
+
+// assertTranscript compares the transcript of the session to its golden file
+func (s *lspSession) assertTranscript() {
+	s.t.Helper()
+	golden.AssertJSON(s.t, filepath.Join("fixtures", "conformance", s.t.Name()+".json"), s.transcript)
+}
+
+func TestConformanceOptions(t *testing.T) {
+	session := newLSPSession(t, nil)
+	session.open("hyprland.conf", heredoc.Doc(`
+		general {
+		    gaps_in = 5
+		    layout = hy3
+		    col.active_border = rgba(33ccffee) rgba(00ff99ee) 45deg
+		}
+
+		decoration {
+		    rounding = 8
+		    blur {
+		        size = 6
+		    }
+		}
+	`))
+	session.call(protocol.MethodTextDocumentHover, &protocol.HoverParams{TextDocumentPositionParams: position("hyprland.conf", 1, 6)}, nil)
+	session.call(protocol.MethodTextDocumentHover, &protocol.HoverParams{TextDocumentPositionParams: position("hyprland.conf", 9, 10)}, nil)
+	session.call(protocol.MethodTextDocumentCompletion, &protocol.CompletionParams{TextDocumentPositionParams: position("hyprland.conf", 2, 13)}, nil)
+	session.call(protocol.MethodTextDocumentDocumentSymbol, &protocol.DocumentSymbolParams{TextDocument: document("hyprland.conf")}, nil)
+	session.call(protocol.MethodTextDocumentDocumentColor, &protocol.DocumentColorParams{TextDocument: document("hyprland.conf")}, nil)
+	session.call(protocol.MethodTextDocumentColorPresentation, &protocol.ColorPresentationParams{
+		TextDocument: document("hyprland.conf"),
+		Color:        protocol.Color{Red: 0.2, Green: 0.8, Blue: 1, Alpha: 1},
+		Range:        protocol.Range{Start: protocol.Position{Line: 3, Character: 24}, End: protocol.Position{Line: 3, Character: 38}},
+	}, nil)
+
+	session.change("hyprland.conf", 2, heredoc.Doc(`
+		general {
+		    gaps_in = 5
+		    layout = master
+		}
+	`))
+	session.assertTranscript()
+}
+
+func TestConformanceBinds(t *testing.T) {
+	session := newLSPSession(t, nil)
+	session.open("hyprland.conf", heredoc.Doc(`
+		$mainMod = SUPER
+		bind = $mainMod, Q, exec, kitty
+		bind = $mainMod, left, movefocus, l
+		bind = $mainMod, R, submap, resize
+		bind = $mainMod, F, fullscren, 0
+		submap = resize
+		binde = , right, resizeactive, 10 0
+		bind = , escape, submap, reset
+		submap = reset
+	`))
+	session.call(protocol.MethodTextDocumentHover, &protocol.HoverParams{TextDocumentPositionParams: position("hyprland.conf", 2, 25)}, nil)
+	session.call(protocol.MethodTextDocumentHover, &protocol.HoverParams{TextDocumentPositionParams: position("hyprland.conf", 1, 2)}, nil)
+	session.call(protocol.MethodTextDocumentCompletion, &protocol.CompletionParams{TextDocumentPositionParams: position("hyprland.conf", 3, 31)}, nil)
+	session.call(protocol.MethodTextDocumentCompletion, &protocol.CompletionParams{TextDocumentPositionParams: position("hyprland.conf", 2, 35)}, nil)
+	session.call(protocol.MethodTextDocumentDefinition, &protocol.DefinitionParams{TextDocumentPositionParams: position("hyprland.conf", 3, 30)}, nil)
+	session.call(protocol.MethodTextDocumentDocumentSymbol, &protocol.DocumentSymbolParams{TextDocument: document("hyprland.conf")}, nil)
+
+	session.change("hyprland.conf", 2, heredoc.Doc(`
+		$mainMod = SUPER
+		bind = $mainMod, F, fullscreen, 0
+	`))
+	session.assertTranscript()
+}
+
+func TestConformanceSourcedFiles(t *testing.T) {
+	session := newLSPSession(t, nil)
+	session.open("colors.conf", heredoc.Doc(`
+		$accent = rgb(ff0000)
+	`))
+	session.open("hyprland.conf", heredoc.Doc(`
+		source = ./colors.conf
+		source = ./missing.conf
+		general {
+		    col.active_border = $accent
+		}
+	`))
+	session.call(protocol.MethodTextDocumentDocumentSymbol, &protocol.DocumentSymbolParams{TextDocument: document("colors.conf")}, nil)
+	session.assertTranscript()
+}
+
+func TestConformanceFormattingAndQuickFixes(t *testing.T) {
+	session := newLSPSession(t, map[string]any{"hyprlandVersion": "v0.41.0"})
+	diagnostics := session.open("hyprland.conf", heredoc.Doc(`
+		master{
+		new_is_master=true   # deprecated
+		    }
+	`))
+	session.call(protocol.MethodTextDocumentFormatting, &protocol.DocumentFormattingParams{
+		TextDocument: document("hyprland.conf"),
+		Options:      protocol.FormattingOptions{TabSize: 4, InsertSpaces: true},
+	}, nil)
+	session.call(protocol.MethodTextDocumentCodeAction, &protocol.CodeActionParams{
+		TextDocument: document("hyprland.conf"),
+		Range:        protocol.Range{Start: protocol.Position{Line: 1, Character: 0}, End: protocol.Position{Line: 1, Character: 0}},
+		Context:      protocol.CodeActionContext{Diagnostics: diagnostics},
+	}, nil)
+	session.assertTranscript()
+}
+
+func TestConformanceUnknownDocument(t *testing.T) {
+	session := newLSPSession(t, nil)
+	session.call(protocol.MethodTextDocumentHover, &protocol.HoverParams{TextDocumentPositionParams: position("never-opened.conf", 0, 0)}, nil)
+	session.call(protocol.MethodTextDocumentCompletion, &protocol.CompletionParams{TextDocumentPositionParams: position("never-opened.conf", 0, 0)}, nil)
+	session.call(protocol.MethodTextDocumentDocumentSymbol, &protocol.DocumentSymbolParams{TextDocument: document("never-opened.conf")}, nil)
+	session.assertTranscript()
+}
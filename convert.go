@@ -0,0 +1,129 @@
+package hyprls
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/ewen-lbh/hyprls/parser"
+	"go.uber.org/multierr"
+	"gopkg.in/yaml.v3"
+)
+
+// DataFormat is a format configurations can be converted from and to
+type DataFormat string
+
+const (
+	FormatHyprlang DataFormat = "hyprlang"
+	FormatJSON     DataFormat = "json"
+	FormatYAML     DataFormat = "yaml"
+	FormatTOML     DataFormat = "toml"
+)
+
+// ParseDataFormat parses the name of a format: hyprlang, json, yaml or toml
+func ParseDataFormat(name string) (DataFormat, error) {
+	switch strings.ToLower(name) {
+	case "hyprlang", "hypr", "conf":
+		return FormatHyprlang, nil
+	case "json":
+		return FormatJSON, nil
+	case "yaml", "yml":
+		return FormatYAML, nil
+	case "toml":
+		return FormatTOML, nil
+	default:
+		return "", fmt.Errorf("unknown format %q, use one of hyprlang, json, yaml or toml", name)
+	}
+}
+
+// DataFormatOfPath guesses the format of a file from its extension
+func DataFormatOfPath(path string) (DataFormat, bool) {
+	format, err := ParseDataFormat(strings.TrimPrefix(filepath.Ext(path), "."))
+	return format, err == nil
+}
+
+// ConvertOptions configures Convert
+type ConvertOptions struct {
+	From DataFormat
+	To   DataFormat
+	// IncludeDefaults writes every option, including the ones that are set to their default value
+	IncludeDefaults bool
+}
+
+// Convert converts a configuration between Hyprlang and structured data formats.
+// Options and keyword statements are converted without loss. Lines that a Configuration cannot hold, such as source statements and plugin options, are left out of the output,
+// and skipped lists them, combined with multierr. The conversion fails if anything else in the input is invalid, and err lists every such problem.
+func Convert(input string, options ConvertOptions) (output string, skipped error, err error) {
+	config, decodeErr := decodeConfiguration(input, options.From)
+	for _, problem := range multierr.Errors(decodeErr) {
+		if errors.Is(problem, parser.ErrNoTypedForm) {
+			skipped = multierr.Append(skipped, problem)
+		} else {
+			err = multierr.Append(err, problem)
+		}
+	}
+	if err != nil {
+		return "", skipped, err
+	}
+
+	encodeOptions := parser.EncodeOptions{SkipDefaults: !options.IncludeDefaults}
+	if options.To == FormatHyprlang {
+		return parser.Encode(config, encodeOptions), skipped, nil
+	}
+
+	data, err := config.ToMap(encodeOptions)
+	if err != nil {
+		return "", skipped, err
+	}
+
+	var encoded bytes.Buffer
+	switch options.To {
+	case FormatJSON:
+		encoder := json.NewEncoder(&encoded)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(data)
+	case FormatYAML:
+		encoder := yaml.NewEncoder(&encoded)
+		encoder.SetIndent(2)
+		err = encoder.Encode(data)
+	case FormatTOML:
+		err = toml.NewEncoder(&encoded).Encode(data)
+	default:
+		return "", skipped, fmt.Errorf("cannot convert to %q", options.To)
+	}
+	if err != nil {
+		return "", skipped, fmt.Errorf("while writing %s: %w", options.To, err)
+	}
+	return encoded.String(), skipped, nil
+}
+
+func decodeConfiguration(input string, format DataFormat) (parser.Configuration, error) {
+	if format == FormatHyprlang {
+		document, err := parser.Parse(input)
+		if err != nil {
+			return parser.Configuration{}, fmt.Errorf("while parsing: %w", err)
+		}
+		return document.Decode()
+	}
+
+	var data map[string]any
+	var err error
+	switch format {
+	case FormatJSON:
+		err = json.Unmarshal([]byte(input), &data)
+	case FormatYAML:
+		err = yaml.Unmarshal([]byte(input), &data)
+	case FormatTOML:
+		err = toml.Unmarshal([]byte(input), &data)
+	default:
+		return parser.Configuration{}, fmt.Errorf("cannot convert from %q", format)
+	}
+	if err != nil {
+		return parser.Configuration{}, fmt.Errorf("while reading %s: %w", format, err)
+	}
+	return parser.FromMap(data)
+}
@@ -0,0 +1,87 @@
+package hyprls
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/ewen-lbh/hyprls/parser"
+	"go.uber.org/multierr"
+)
+
+func TestConvertRoundTrip(t *testing.T) {
+	input := heredoc.Doc(`
+		$mod = SUPER
+
+		env = XCURSOR_SIZE,24
+
+		general {
+		    gaps_in = 8
+		    col.active_border = rgba(ff0000ff) rgba(00ff00ff) 45deg
+		}
+
+		decoration {
+		    active_opacity = 0.9
+		}
+
+		input {
+		    sensitivity = 0.5
+		}
+
+		monitor = DP-1, 2560x1440@144, 0x0, 1
+
+		windowrulev2 = float, class:^(pavucontrol)$
+
+		exec-once = notify-send ##1
+
+		bind = SUPER, R, submap, resize
+
+		submap = resize
+		binde = , right, resizeactive, 10 0
+		submap = reset
+	`)
+
+	for _, format := range []DataFormat{FormatJSON, FormatYAML, FormatTOML} {
+		data, _, err := Convert(input, ConvertOptions{From: FormatHyprlang, To: format})
+		if err != nil {
+			t.Fatalf("while converting to %s: %s", format, err)
+		}
+		back, _, err := Convert(data, ConvertOptions{From: format, To: FormatHyprlang})
+		if err != nil {
+			t.Fatalf("while converting from %s: %s\n%s", format, err, data)
+		}
+		if back != input {
+			t.Errorf("%s round trip changed the configuration:\n%s\nthrough:\n%s", format, back, data)
+		}
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	output, skipped, err := Convert("source = ~/other.conf\ngeneral:gaps_in = 4\n", ConvertOptions{From: FormatHyprlang, To: FormatJSON})
+	if err != nil {
+		t.Errorf("expected lines that cannot be represented to be skipped, got %v", err)
+	}
+	if errs := multierr.Errors(skipped); len(errs) != 1 || !errors.Is(errs[0], parser.ErrNoTypedForm) {
+		t.Errorf("expected source statements to be skipped, got %v", skipped)
+	}
+	if !strings.Contains(output, `"gaps_in": 4`) {
+		t.Errorf("expected the rest of the configuration to be converted, got %s", output)
+	}
+
+	_, _, err = Convert(`{"general": {"gaps_in": [1], "nope": 1}, "monitors": {}}`, ConvertOptions{From: FormatJSON, To: FormatHyprlang})
+	expected := []string{
+		"general:gaps_in: expected a int, got a list",
+		"general:nope: unknown option",
+		"monitors: json: cannot unmarshal object into Go value of type []parser.Monitor",
+	}
+	errs := multierr.Errors(err)
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got %v", len(expected), errs)
+	}
+	for i, err := range errs {
+		if err.Error() != expected[i] {
+			t.Errorf("unexpected error %q, expected %q", err, expected[i])
+		}
+	}
+}
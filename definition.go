@@ -0,0 +1,39 @@
+package hyprls
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ewen-lbh/hyprls/parser"
+	"go.lsp.dev/protocol"
+)
+
+func (h Handler) Definition(ctx context.Context, params *protocol.DefinitionParams) ([]protocol.Location, error) {
+	document, err := h.state.parse(params.TextDocument.URI)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing: %w", err)
+	}
+
+	stmt := currentStatement(document, params.Position)
+	if stmt == nil {
+		return []protocol.Location{}, nil
+	}
+
+	// bind = ..., submap, name: go to the submap's declaration
+	bind, err := parser.ParseBind(*stmt)
+	if err != nil || bind.Dispatcher != "submap" {
+		return []protocol.Location{}, nil
+	}
+
+	locations := make([]protocol.Location, 0)
+	for _, submap := range document.Submaps() {
+		if submap.Name != bind.Params {
+			continue
+		}
+		locations = append(locations, protocol.Location{
+			URI:   params.TextDocument.URI,
+			Range: submap.Opening.LSPRange(),
+		})
+	}
+	return locations, nil
+}
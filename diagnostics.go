@@ -0,0 +1,287 @@
+package hyprls
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ewen-lbh/hyprls/parser"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"go.lsp.dev/protocol"
+	"go.uber.org/zap"
+)
+
+// diagnosticRule is a check run on documents to report problems to the user
+type diagnosticRule struct {
+	// Code identifies the rule, it is sent along with the diagnostics it emits
+	Code     string
+	Severity protocol.DiagnosticSeverity
+	Check    func(ctx diagnosticContext) []protocol.Diagnostic
+}
+
+// diagnosticContext is what rules are checked against. Rules only report diagnostics for File, but can look at the whole configuration it is part of.
+type diagnosticContext struct {
+	File  *parser.ConfigFile
+	Graph *parser.Graph
+	// Version is the Hyprland version the file targets, or "" for any version
+	Version string
+	// Keybinds is the analysis of the keybinds of Graph, which several rules share
+	Keybinds keybindsReport
+}
+
+var diagnosticRules = []diagnosticRule{
+	{
+		Code:     "submap-unclosed",
+		Severity: protocol.DiagnosticSeverityWarning,
+		Check:    checkSubmapsAreClosed,
+	},
+	{
+		Code:     "submap-no-exit",
+		Severity: protocol.DiagnosticSeverityWarning,
+		Check:    checkSubmapsHaveExitBind,
+	},
+	{
+		Code:     "unknown-submap",
+		Severity: protocol.DiagnosticSeverityError,
+		Check:    checkSubmapsExist,
+	},
+	{
+		Code:     "bind-conflict",
+		Severity: protocol.DiagnosticSeverityWarning,
+		Check:    checkBindConflicts,
+	},
+	{
+		Code:     "unbind-unused",
+		Severity: protocol.DiagnosticSeverityHint,
+		Check:    checkUselessUnbinds,
+	},
+	{
+		Code:     "unknown-dispatcher",
+		Severity: protocol.DiagnosticSeverityWarning,
+		Check:    checkDispatchersExist,
+	},
+	{
+		Code:     "source-not-found",
+		Severity: protocol.DiagnosticSeverityError,
+		Check:    checkSourcesExist,
+	},
+	{
+		Code:     "option-version",
+		Severity: protocol.DiagnosticSeverityError,
+		Check:    checkOptionsExistInVersion,
+	},
+	{
+		Code:     "deprecated",
+		Severity: protocol.DiagnosticSeverityWarning,
+		Check:    checkDeprecations,
+	},
+	{
+		Code:     "option-value",
+		Severity: protocol.DiagnosticSeverityWarning,
+		Check:    checkOptionValues,
+	},
+}
+
+func diagnose(ctx diagnosticContext) []protocol.Diagnostic {
+	diagnostics := make([]protocol.Diagnostic, 0)
+	for _, rule := range diagnosticRules {
+		for _, diag := range rule.Check(ctx) {
+			diag.Code = rule.Code
+			diag.Severity = rule.Severity
+			diag.Source = "hyprls"
+			diagnostics = append(diagnostics, diag)
+		}
+	}
+	return diagnostics
+}
+
+func (h Handler) publishDiagnostics(ctx context.Context, uri protocol.URI) {
+	if h.client == nil {
+		return
+	}
+
+	graph, err := h.state.configGraph(uri)
+	if err != nil {
+		h.Logger.Debug("LSP:publishDiagnostics: could not load configuration", zap.Error(err))
+		return
+	}
+
+	file, ok := graph.Files[uri.Filename()]
+	if !ok {
+		return
+	}
+
+	err = h.client.PublishDiagnostics(ctx, &protocol.PublishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: diagnose(diagnosticContext{File: file, Graph: graph, Version: h.state.targetVersion(uri), Keybinds: analyzeKeybinds(graph)}),
+	})
+	if err != nil {
+		h.Logger.Debug("LSP:publishDiagnostics: could not publish", zap.Error(err))
+	}
+}
+
+func checkSubmapsAreClosed(ctx diagnosticContext) []protocol.Diagnostic {
+	diagnostics := make([]protocol.Diagnostic, 0)
+	for _, submap := range ctx.File.Document.Submaps() {
+		if submap.Reset != nil {
+			continue
+		}
+		diagnostics = append(diagnostics, protocol.Diagnostic{
+			Range:   submap.Opening.LSPRange(),
+			Message: fmt.Sprintf("Submap %q is not closed with %q, so everything after it is part of the submap", submap.Name, "submap = "+parser.SubmapReset),
+		})
+	}
+	return diagnostics
+}
+
+func checkSubmapsHaveExitBind(ctx diagnosticContext) []protocol.Diagnostic {
+	diagnostics := make([]protocol.Diagnostic, 0)
+	// exits maps every submap of the configuration to the submaps its binds go to
+	exits := make(map[string][]string)
+	for _, file := range ctx.Graph.Files {
+		for _, submap := range file.Document.Submaps() {
+			for _, bind := range submap.Binds() {
+				if bind.Dispatcher == "submap" {
+					exits[submap.Name] = append(exits[submap.Name], strings.TrimSpace(bind.Params))
+				}
+			}
+		}
+	}
+
+	for _, submap := range ctx.File.Document.Submaps() {
+		if len(exits[submap.Name]) == 0 {
+			diagnostics = append(diagnostics, protocol.Diagnostic{
+				Range:   submap.Opening.LSPRange(),
+				Message: fmt.Sprintf("No bind exits submap %q, you won't be able to leave it once you enter it. Add a bind such as %q", submap.Name, "bind = , escape, submap, "+parser.SubmapReset),
+			})
+		} else if !reachesSubmapReset(submap.Name, exits, make(map[string]bool)) {
+			diagnostics = append(diagnostics, protocol.Diagnostic{
+				Range:   submap.Opening.LSPRange(),
+				Message: fmt.Sprintf("Binds of submap %q only go to other submaps, none of which go back to %q: you won't be able to leave them once you enter it. Add a bind such as %q", submap.Name, parser.SubmapReset, "bind = , escape, submap, "+parser.SubmapReset),
+			})
+		}
+	}
+	return diagnostics
+}
+
+// reachesSubmapReset returns true if a chain of binds goes from the submap back to the global one
+func reachesSubmapReset(submap string, exits map[string][]string, visited map[string]bool) bool {
+	if visited[submap] {
+		return false
+	}
+	visited[submap] = true
+	for _, target := range exits[submap] {
+		if target == parser.SubmapReset || reachesSubmapReset(target, exits, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkSubmapsExist(ctx diagnosticContext) []protocol.Diagnostic {
+	diagnostics := make([]protocol.Diagnostic, 0)
+	declared := make(map[string]bool)
+	for _, file := range ctx.Graph.Files {
+		for _, submap := range file.Document.Submaps() {
+			declared[submap.Name] = true
+		}
+	}
+
+	ctx.File.Document.WalkStatements(func(stmt *parser.Statement) {
+		bind, err := parser.ParseBind(*stmt)
+		if err != nil || bind.Dispatcher != "submap" {
+			return
+		}
+		if bind.Params == parser.SubmapReset || declared[bind.Params] {
+			return
+		}
+		diagnostics = append(diagnostics, protocol.Diagnostic{
+			Range:   stmt.LSPRange(),
+			Message: fmt.Sprintf("Submap %q is not declared anywhere", bind.Params),
+		})
+	})
+	return diagnostics
+}
+
+func checkSourcesExist(ctx diagnosticContext) []protocol.Diagnostic {
+	diagnostics := make([]protocol.Diagnostic, 0)
+	for _, sourceErr := range ctx.Graph.Errors {
+		if sourceErr.File != ctx.File.Path {
+			continue
+		}
+		diagnostics = append(diagnostics, protocol.Diagnostic{
+			Range:   sourceErr.Statement.LSPRange(),
+			Message: fmt.Sprintf("Could not source %s: %s", strings.TrimSpace(sourceErr.Statement.ValueRaw), sourceErr.Err),
+		})
+	}
+	return diagnostics
+}
+
+func checkOptionsExistInVersion(ctx diagnosticContext) []protocol.Diagnostic {
+	diagnostics := make([]protocol.Diagnostic, 0)
+	if ctx.Version == "" {
+		return diagnostics
+	}
+
+	for _, entry := range ctx.File.Entries() {
+		if entry.Assignment == nil {
+			continue
+		}
+		path := strings.Join(parser.OptionPath(entry.SectionPath, entry.Assignment.Key), parser_data.PathSeparator)
+		_, def := parser_data.FindVariableByPath(path)
+		if def == nil || def.AvailableIn(ctx.Version) {
+			continue
+		}
+
+		message := fmt.Sprintf("Option %s was removed in %s", path, def.RemovedIn)
+		if migration, ok := parser_data.FindOptionMigration(path); ok {
+			message += fmt.Sprintf(", use %s instead", migration.To)
+		}
+		if def.AddedIn != "" && parser_data.CompareVersions(ctx.Version, def.AddedIn) < 0 {
+			message = fmt.Sprintf("Option %s was added in %s, it is not available in %s", path, def.AddedIn, ctx.Version)
+		}
+		diagnostics = append(diagnostics, protocol.Diagnostic{
+			Range:   entry.Assignment.LSPRange(),
+			Message: message,
+		})
+	}
+	return diagnostics
+}
+
+func checkDeprecations(ctx diagnosticContext) []protocol.Diagnostic {
+	diagnostics := make([]protocol.Diagnostic, 0)
+	for _, deprecation := range ctx.File.Deprecations(ctx.Version) {
+		// Options that don't exist anymore in the targeted version are reported by the option-version rule
+		if _, def := parser_data.FindVariableByPath(deprecation.Path); def != nil && !def.AvailableIn(ctx.Version) {
+			continue
+		}
+		diagnostics = append(diagnostics, protocol.Diagnostic{
+			Range:   deprecation.Range,
+			Message: deprecation.Message(),
+			Tags:    []protocol.DiagnosticTag{protocol.DiagnosticTagDeprecated},
+		})
+	}
+	return diagnostics
+}
+
+func checkOptionValues(ctx diagnosticContext) []protocol.Diagnostic {
+	diagnostics := make([]protocol.Diagnostic, 0)
+	for _, entry := range ctx.File.Entries() {
+		// Values that use custom variables are only known once the whole configuration is evaluated
+		if entry.Assignment == nil || strings.Contains(entry.Assignment.ValueRaw, "$") {
+			continue
+		}
+		path := strings.Join(parser.OptionPath(entry.SectionPath, entry.Assignment.Key), parser_data.PathSeparator)
+		_, def := parser_data.FindVariableByPath(path)
+		if def == nil {
+			continue
+		}
+		if problem, ok := def.CheckValue(entry.Assignment.ValueRaw); !ok {
+			diagnostics = append(diagnostics, protocol.Diagnostic{
+				Range:   entry.Assignment.LSPRange(),
+				Message: fmt.Sprintf("Invalid value %s for %s: %s", strings.TrimSpace(entry.Assignment.ValueRaw), path, problem),
+			})
+		}
+	}
+	return diagnostics
+}
@@ -0,0 +1,276 @@
+package hyprls
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/ewen-lbh/hyprls/internal/golden"
+	"github.com/ewen-lbh/hyprls/parser"
+	"go.lsp.dev/protocol"
+)
+
+// diagnoseFiles runs all diagnostic rules on the file at path, which is part of a configuration made of files, whose keys are absolute paths
+func diagnoseFiles(t *testing.T, files map[string]string, root string, path string) []protocol.Diagnostic {
+	t.Helper()
+	graph, err := parser.LoadGraph(root, func(p string) (string, error) {
+		if contents, ok := files[p]; ok {
+			return contents, nil
+		}
+		return "", fmt.Errorf("open %s: %w", p, os.ErrNotExist)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return diagnose(diagnosticContext{File: graph.Files[path], Graph: graph, Version: graph.TargetVersion(path), Keybinds: analyzeKeybinds(graph)})
+}
+
+// diagnosticLines returns the lines on which diagnostics were emitted, grouped by rule
+func diagnosticLines(diagnostics []protocol.Diagnostic) map[string][]uint32 {
+	lines := make(map[string][]uint32)
+	for _, diag := range diagnostics {
+		lines[diag.Code.(string)] = append(lines[diag.Code.(string)], diag.Range.Start.Line)
+	}
+	return lines
+}
+
+func assertDiagnosticLines(t *testing.T, diagnostics []protocol.Diagnostic, expected map[string][]uint32) {
+	t.Helper()
+	actual := diagnosticLines(diagnostics)
+	for code, lines := range expected {
+		if fmt.Sprint(actual[code]) != fmt.Sprint(lines) {
+			t.Errorf("%s: expected diagnostics on lines %v, got %v", code, lines, actual[code])
+		}
+	}
+}
+
+func TestSubmapDiagnostics(t *testing.T) {
+	diagnostics := diagnoseFiles(t, map[string]string{
+		"/hypr/hyprland.conf": heredoc.Doc(`
+			bind = ALT, R, submap, resize
+			bind = ALT, P, submap, passthrough
+			submap = resize
+			binde = , right, resizeactive, 10 0
+			binde = , right, resizeactive, 20 0
+			submap = reset
+
+			bind = , right, movefocus, r
+			submap = passthru
+			bind = , escape, submap, reset
+		`),
+	}, "/hypr/hyprland.conf", "/hypr/hyprland.conf")
+
+	assertDiagnosticLines(t, diagnostics, map[string][]uint32{
+		"submap-unclosed": {8},
+		"submap-no-exit":  {2},
+		"unknown-submap":  {1},
+		"bind-conflict":   {3, 4},
+	})
+}
+
+func TestSubmapExitChains(t *testing.T) {
+	files := map[string]string{
+		"/hypr/hyprland.conf": heredoc.Doc(`
+			source = ./submaps.conf
+			bind = ALT, R, submap, first
+			submap = first
+			bind = , escape, submap, second
+			submap = reset
+			submap = second
+			bind = , escape, submap, first
+			submap = reset
+			submap = third
+			bind = , escape, submap, fourth
+			submap = reset
+		`),
+		"/hypr/submaps.conf": heredoc.Doc(`
+			submap = fourth
+			bind = , escape, submap, reset
+			submap = reset
+		`),
+	}
+
+	diagnostics := diagnoseFiles(t, files, "/hypr/hyprland.conf", "/hypr/hyprland.conf")
+	assertDiagnosticLines(t, diagnostics, map[string][]uint32{"submap-no-exit": {2, 5}})
+	for _, diag := range diagnostics {
+		if diag.Code == "submap-no-exit" && !strings.Contains(diag.Message, "only go to other submaps") {
+			t.Errorf("unexpected message %q", diag.Message)
+		}
+	}
+}
+
+func TestKeybindConflictsAcrossSources(t *testing.T) {
+	files := map[string]string{
+		"/hypr/hyprland.conf": heredoc.Doc(`
+			$mainMod = SUPER
+			source = ./binds.conf
+			source = ./missing.conf
+			bind = WIN, Q, killactive
+			bindr = SUPER, Q, exec, notify-send released
+			unbind = CONTROL, X
+			bind = LOGO SHIFT, Return, exec, kitty
+		`),
+		"/hypr/binds.conf": heredoc.Doc(`
+			bind = $mainMod, q, exec, kitty
+			bind = SUPER_SHIFT, return, exec, foot
+			unbind = SUPER SHIFT, Return
+			bind = CTRL, X, exec, firefox
+		`),
+	}
+
+	diagnostics := diagnoseFiles(t, files, "/hypr/hyprland.conf", "/hypr/hyprland.conf")
+	assertDiagnosticLines(t, diagnostics, map[string][]uint32{
+		"bind-conflict":    {3},
+		"unbind-unused":    nil,
+		"source-not-found": {2},
+	})
+	for _, diag := range diagnostics {
+		if diag.Code != "bind-conflict" {
+			continue
+		}
+		if len(diag.RelatedInformation) != 1 || diag.RelatedInformation[0].Location.URI.Filename() != "/hypr/binds.conf" {
+			t.Errorf("expected conflict to link to binds.conf, got %#v", diag.RelatedInformation)
+		}
+		if diag.Message != "SUPER + q is bound 2 times" {
+			t.Errorf("unexpected message: %q", diag.Message)
+		}
+	}
+
+	diagnostics = diagnoseFiles(t, files, "/hypr/hyprland.conf", "/hypr/binds.conf")
+	assertDiagnosticLines(t, diagnostics, map[string][]uint32{
+		"bind-conflict": {0},
+		"unbind-unused": nil,
+	})
+
+	files["/hypr/binds.conf"] = "unbind = SUPER, F"
+	diagnostics = diagnoseFiles(t, files, "/hypr/hyprland.conf", "/hypr/binds.conf")
+	assertDiagnosticLines(t, diagnostics, map[string][]uint32{
+		"unbind-unused": {0},
+	})
+}
+
+func TestOptionVersionDiagnostics(t *testing.T) {
+	files := map[string]string{
+		"/hypr/hyprland.conf": heredoc.Doc(`
+			# My configuration
+			# hyprlang version 0.41
+
+			general {
+				no_cursor_warps = true
+				gaps_in = 4
+			}
+			decoration:drop_shadow = false
+			source = ./old.conf
+		`),
+		"/hypr/old.conf": heredoc.Doc(`
+			# hyprlang version v0.40.0
+			general:no_cursor_warps = true
+		`),
+	}
+
+	diagnostics := diagnoseFiles(t, files, "/hypr/hyprland.conf", "/hypr/hyprland.conf")
+	assertDiagnosticLines(t, diagnostics, map[string][]uint32{"option-version": {4}})
+	for _, diag := range diagnostics {
+		if diag.Code == "option-version" && diag.Message != "Option general:no_cursor_warps was removed in v0.41.0, use cursor:no_warps instead" {
+			t.Errorf("unexpected message %q", diag.Message)
+		}
+	}
+
+	assertDiagnosticLines(t, diagnoseFiles(t, files, "/hypr/hyprland.conf", "/hypr/old.conf"), map[string][]uint32{"option-version": nil})
+}
+
+func TestDeprecationDiagnostics(t *testing.T) {
+	files := map[string]string{
+		"/hypr/hyprland.conf": heredoc.Doc(`
+			decoration {
+				drop_shadow = false
+			}
+			windowrule = float, pavucontrol
+		`),
+	}
+
+	diagnostics := diagnoseFiles(t, files, "/hypr/hyprland.conf", "/hypr/hyprland.conf")
+	assertDiagnosticLines(t, diagnostics, map[string][]uint32{"deprecated": {1, 3}, "option-version": nil})
+	for _, diag := range diagnostics {
+		if diag.Code == "deprecated" && (len(diag.Tags) != 1 || diag.Tags[0] != protocol.DiagnosticTagDeprecated) {
+			t.Errorf("expected deprecations to be tagged, got %+v", diag)
+		}
+	}
+
+	// Options that were removed in the targeted version are errors instead, and windowrule is only deprecated since v0.48.0
+	contents := files["/hypr/hyprland.conf"]
+	files["/hypr/hyprland.conf"] = "# hyprlang version v0.45.0\n" + contents
+	diagnostics = diagnoseFiles(t, files, "/hypr/hyprland.conf", "/hypr/hyprland.conf")
+	assertDiagnosticLines(t, diagnostics, map[string][]uint32{"deprecated": nil, "option-version": {2}})
+
+	files["/hypr/hyprland.conf"] = "# hyprlang version v0.48.0\n" + contents
+	diagnostics = diagnoseFiles(t, files, "/hypr/hyprland.conf", "/hypr/hyprland.conf")
+	assertDiagnosticLines(t, diagnostics, map[string][]uint32{"deprecated": {4}, "option-version": {2}})
+}
+
+func TestOptionValueDiagnostics(t *testing.T) {
+	files := map[string]string{
+		"/hypr/hyprland.conf": heredoc.Doc(`
+			$passes = 8
+			general {
+				layout = hy3
+				gaps_in = 4
+			}
+			decoration:blur:passes = 6
+			decoration:blur:size = $passes
+			decoration:active_opacity = 0.9
+			input:accel_profile = custom 200 0.0 0.5
+		`),
+	}
+
+	diagnostics := diagnoseFiles(t, files, "/hypr/hyprland.conf", "/hypr/hyprland.conf")
+	assertDiagnosticLines(t, diagnostics, map[string][]uint32{"option-value": {2, 5}})
+	for _, diag := range diagnostics {
+		if diag.Code == "option-value" && diag.Range.Start.Line == 2 && diag.Message != "Invalid value hy3 for general:layout: must be one of dwindle, master" {
+			t.Errorf("unexpected message %q", diag.Message)
+		}
+	}
+}
+
+func TestUnknownDispatcherDiagnostics(t *testing.T) {
+	files := map[string]string{
+		"/hypr/hyprland.conf": heredoc.Doc(`
+			bind = SUPER, Q, killactive,
+			bind = SUPER, H, movefocuss, l
+			bindm = SUPER, mouse:273, resizewindow
+			bind = SUPER, grave, hyprexpo:expo, toggle
+			bind = SUPER, P, pseudo,
+		`),
+	}
+
+	diagnostics := diagnoseFiles(t, files, "/hypr/hyprland.conf", "/hypr/hyprland.conf")
+	assertDiagnosticLines(t, diagnostics, map[string][]uint32{"unknown-dispatcher": {1}})
+}
+
+// TestDiagnosticsOfFixtures checks the diagnostics of the parser's fixtures, each one being the main configuration file.
+// Run the tests with -update to rewrite the expected diagnostics in parser/fixtures/golden/.
+func TestDiagnosticsOfFixtures(t *testing.T) {
+	// Sourced files are resolved relative to the home directory, which should not change the messages
+	t.Setenv("HOME", "/home/hypr")
+	fixtures, err := filepath.Glob(filepath.Join("parser", "fixtures", "*.hl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, fixture := range fixtures {
+		name := strings.TrimSuffix(filepath.Base(fixture), ".hl")
+		t.Run(name, func(t *testing.T) {
+			contents, err := os.ReadFile(fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			path := "/home/hypr/.config/hypr/" + name + ".hl"
+			diagnostics := diagnoseFiles(t, map[string]string{path: string(contents)}, path, path)
+			golden.AssertJSON(t, filepath.Join("parser", "fixtures", "golden", name+".diagnostics.json"), diagnostics)
+		})
+	}
+}
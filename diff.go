@@ -0,0 +1,56 @@
+package hyprls
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ewen-lbh/hyprls/parser"
+)
+
+// Diff compares the effective configurations whose main files are at oldPath and newPath:
+// each one is resolved with all the files it sources and its custom variables expanded, so that moving options around or into other files is not a change.
+// Assignments and statements that cannot be decoded, such as plugin options or invalid values, are left out of the comparison.
+func Diff(oldPath, newPath string) ([]parser.Change, error) {
+	return diffConfigurations(oldPath, newPath, readFile)
+}
+
+func diffConfigurations(oldPath, newPath string, read parser.FileReader) ([]parser.Change, error) {
+	old, err := effectiveConfiguration(oldPath, read)
+	if err != nil {
+		return nil, err
+	}
+	new, err := effectiveConfiguration(newPath, read)
+	if err != nil {
+		return nil, err
+	}
+	return parser.Diff(old, new), nil
+}
+
+// effectiveConfiguration decodes the configuration whose main file is at path, including the files it sources
+func effectiveConfiguration(path string, read parser.FileReader) (parser.Configuration, error) {
+	graph, err := parser.LoadGraph(path, read)
+	if err != nil {
+		return parser.Configuration{}, fmt.Errorf("while loading %s: %w", path, err)
+	}
+	// What cannot be decoded is already reported by diagnostics
+	config, _ := graph.Decode()
+	return config, nil
+}
+
+// WriteHumanDiff writes the changes one per line, e.g. "decoration:blur:size 8 → 6" or "+ bind SUPER + q: exec, kitty"
+func WriteHumanDiff(w io.Writer, changes []parser.Change) error {
+	for _, change := range changes {
+		if _, err := fmt.Fprintln(w, change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteJSONDiff writes the changes as a JSON array of parser.Change
+func WriteJSONDiff(w io.Writer, changes []parser.Change) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(changes)
+}
@@ -0,0 +1,49 @@
+package hyprls
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+)
+
+func TestDiffFollowsSources(t *testing.T) {
+	files := map[string]string{
+		"/old/hyprland.conf": heredoc.Doc(`
+			$mod = SUPER
+			general {
+				gaps_in = 5
+				border_size = 2
+			}
+			bind = $mod, Q, killactive
+			bind = $mod, F, fullscreen
+		`),
+		"/new/hyprland.conf": heredoc.Doc(`
+			$mod = SUPER
+			source = ./binds.conf
+			general:border_size = 3
+			general:gaps_in = 5
+			unbind = SUPER, F
+		`),
+		"/new/binds.conf": heredoc.Doc(`
+			bind = $mod, F, fullscreen
+			bind = SUPER, q, killactive
+		`),
+	}
+
+	changes, err := diffConfigurations("/old/hyprland.conf", "/new/hyprland.conf", func(path string) (string, error) {
+		if contents, ok := files[path]; ok {
+			return contents, nil
+		}
+		return "", fmt.Errorf("open %s: %w", path, os.ErrNotExist)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "[general:border_size 2 → 3 - bind SUPER + f: fullscreen]"
+	if fmt.Sprint(changes) != expected {
+		t.Errorf("unexpected changes:\n%v\nexpected:\n%s", changes, expected)
+	}
+}
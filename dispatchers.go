@@ -0,0 +1,149 @@
+package hyprls
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ewen-lbh/hyprls/parser"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"go.lsp.dev/protocol"
+)
+
+// bindArgument is the argument of a bind statement that the cursor is on
+type bindArgument struct {
+	// Name is one of mods, key, description, dispatcher or params
+	Name string
+	Bind parser.Bind
+	// Start and End are the columns of the argument in the line, without the whitespace around it
+	Start, End int
+}
+
+// bindArgumentAt returns the argument of the bind statement under the cursor. ok is false if the cursor is not after the equal sign of a bind statement.
+func bindArgumentAt(document parser.Section, line string, position protocol.Position) (argument bindArgument, ok bool) {
+	stmt := currentStatement(document, position)
+	if stmt == nil || !stmt.IsBind() {
+		return bindArgument{}, false
+	}
+	equals := strings.Index(line, "=")
+	cursor := min(int(position.Character), len(line))
+	if equals == -1 || cursor <= equals {
+		return bindArgument{}, false
+	}
+
+	// The bind is still returned when it has too few arguments, which is the case while typing them
+	bind, _ := parser.ParseBind(*stmt)
+	names := []string{"mods", "key", "dispatcher", "params"}
+	if bind.HasFlag('d') {
+		names = []string{"mods", "key", "description", "dispatcher", "params"}
+	}
+
+	// Parameters take the rest of the line, commas included
+	index := min(strings.Count(line[equals+1:cursor], ","), len(names)-1)
+	start := equals + 1
+	for range index {
+		start += strings.Index(line[start:], ",") + 1
+	}
+	end := len(line)
+	if index < len(names)-1 {
+		if comma := strings.Index(line[start:], ","); comma != -1 {
+			end = start + comma
+		}
+	}
+
+	value := line[start:end]
+	start += len(value) - len(strings.TrimLeft(value, " \t"))
+	end = max(start, end-(len(value)-len(strings.TrimRight(value, " \t"))))
+	return bindArgument{Name: names[index], Bind: bind, Start: start, End: end}, true
+}
+
+// dispatcherCompletions suggests dispatchers when the cursor is on the dispatcher of a bind, and values of the parameter when the cursor is on the parameters of a dispatcher that takes directions or toggles
+func dispatcherCompletions(document parser.Section, line string, position protocol.Position) []protocol.CompletionItem {
+	argument, ok := bindArgumentAt(document, line, position)
+	if !ok {
+		return nil
+	}
+	editRange := protocol.Range{
+		Start: protocol.Position{Line: position.Line, Character: uint32(min(argument.Start, int(position.Character)))},
+		End:   position,
+	}
+
+	items := make([]protocol.CompletionItem, 0)
+	switch argument.Name {
+	case "dispatcher":
+		for _, dispatcher := range parser_data.Dispatchers {
+			items = append(items, protocol.CompletionItem{
+				Label:  dispatcher.Name,
+				Kind:   protocol.CompletionItemKindFunction,
+				Detail: "params: " + dispatcher.Params,
+				Documentation: protocol.MarkupContent{
+					Kind:  protocol.Markdown,
+					Value: fmt.Sprintf("%s\n\n[docs](%s)", dispatcher.Description, dispatcher.DocumentationLink()),
+				},
+				TextEdit: &protocol.TextEdit{Range: editRange, NewText: dispatcher.Name},
+			})
+		}
+	case "params":
+		dispatcher, found := parser_data.FindDispatcher(argument.Bind.Dispatcher)
+		if !found || len(dispatcher.ParameterKinds) == 0 {
+			return nil
+		}
+		values := make([]string, 0)
+		switch dispatcher.ParameterKinds[0] {
+		case parser_data.ParameterDirection:
+			values = parser_data.Directions
+		case parser_data.ParameterToggle:
+			values = parser_data.Toggles
+		}
+		for _, value := range values {
+			items = append(items, protocol.CompletionItem{
+				Label:    value,
+				Kind:     protocol.CompletionItemKindEnumMember,
+				TextEdit: &protocol.TextEdit{Range: editRange, NewText: value},
+			})
+		}
+	}
+	return items
+}
+
+// dispatcherHover returns the documentation of the dispatcher of a bind, when the cursor is on it
+func dispatcherHover(document parser.Section, line string, position protocol.Position) *protocol.Hover {
+	argument, ok := bindArgumentAt(document, line, position)
+	if !ok || argument.Name != "dispatcher" {
+		return nil
+	}
+	dispatcher, found := parser_data.FindDispatcher(argument.Bind.Dispatcher)
+	if !found {
+		return nil
+	}
+	return &protocol.Hover{
+		Contents: protocol.MarkupContent{
+			Kind:  protocol.Markdown,
+			Value: fmt.Sprintf("### %s [[docs]](%s)\n%s\n\n- Params: %s", dispatcher.Name, dispatcher.DocumentationLink(), dispatcher.Description, dispatcher.Params),
+		},
+		Range: &protocol.Range{
+			Start: protocol.Position{Line: position.Line, Character: uint32(argument.Start)},
+			End:   protocol.Position{Line: position.Line, Character: uint32(argument.End)},
+		},
+	}
+}
+
+func checkDispatchersExist(ctx diagnosticContext) []protocol.Diagnostic {
+	diagnostics := make([]protocol.Diagnostic, 0)
+	for _, entry := range ctx.File.Entries() {
+		if entry.Statement == nil || !entry.Statement.IsBind() {
+			continue
+		}
+		bind, err := parser.ParseBind(*entry.Statement)
+		// Dispatchers of plugins are namespaced, as in hyprexpo:expo
+		if err != nil || bind.Dispatcher == "" || strings.Contains(bind.Dispatcher, ":") {
+			continue
+		}
+		if _, found := parser_data.FindDispatcher(bind.Dispatcher); !found {
+			diagnostics = append(diagnostics, protocol.Diagnostic{
+				Range:   entry.Statement.LSPRange(),
+				Message: fmt.Sprintf("Unknown dispatcher %s", bind.Dispatcher),
+			})
+		}
+	}
+	return diagnostics
+}
@@ -0,0 +1,81 @@
+package hyprls
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/ewen-lbh/hyprls/parser"
+	"go.lsp.dev/protocol"
+)
+
+func TestBindArgumentAt(t *testing.T) {
+	contents := heredoc.Doc(`
+		bind = SUPER, Q, killactive,
+		bindd = SUPER, left, Focus left, movefocus, l
+		bind = SUPER, E, exec, notify-send a,b
+	`)
+	document, err := parser.Parse(contents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(contents, "\n")
+
+	tests := []struct {
+		line, character int
+		name            string
+		text            string
+	}{
+		{0, 8, "mods", "SUPER"},
+		{0, 19, "dispatcher", "killactive"},
+		{1, 23, "description", "Focus left"},
+		{1, 37, "dispatcher", "movefocus"},
+		{1, 45, "params", "l"},
+		{2, 35, "params", "notify-send a,b"},
+	}
+	for _, test := range tests {
+		argument, ok := bindArgumentAt(document, lines[test.line], protocol.Position{Line: uint32(test.line), Character: uint32(test.character)})
+		if !ok {
+			t.Errorf("%d:%d: no argument found", test.line, test.character)
+			continue
+		}
+		if text := lines[test.line][argument.Start:argument.End]; argument.Name != test.name || text != test.text {
+			t.Errorf("%d:%d: expected %s %q, got %s %q", test.line, test.character, test.name, test.text, argument.Name, text)
+		}
+	}
+
+	if _, ok := bindArgumentAt(document, lines[0], protocol.Position{Line: 0, Character: 2}); ok {
+		t.Error("the keyword of a bind should not be an argument")
+	}
+}
+
+func TestDispatcherCompletions(t *testing.T) {
+	contents := "bind = SUPER, H, movefo\nbind = SUPER, H, movefocus, \n"
+	document, err := parser.Parse(contents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(contents, "\n")
+
+	items := dispatcherCompletions(document, lines[0], protocol.Position{Line: 0, Character: 23})
+	if !hasCompletion(items, "movefocus") || !hasCompletion(items, "exec") {
+		t.Errorf("expected dispatchers to be suggested, got %v", items)
+	}
+	if edit := items[0].TextEdit; edit.Range.Start.Character != 17 {
+		t.Errorf("expected the typed dispatcher name to be replaced, got range %v", edit.Range)
+	}
+
+	items = dispatcherCompletions(document, lines[1], protocol.Position{Line: 1, Character: 28})
+	if len(items) != 4 || !hasCompletion(items, "l") {
+		t.Errorf("expected directions to be suggested, got %v", items)
+	}
+}
+
+func hasCompletion(items []protocol.CompletionItem, label string) bool {
+	for _, item := range items {
+		if item.Label == label {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+package hyprls
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+)
+
+// Doc is the documentation of a variable, a keyword, a dispatcher or a section of the configuration
+type Doc struct {
+	// Kind is one of "variable", "keyword", "dispatcher" or "section"
+	Kind string `json:"kind"`
+	// Path is the path of the variable or section, e.g. decoration:blur:size, the name of the keyword, or the name of the dispatcher prefixed with DispatcherDocPrefix
+	Path        string   `json:"path"`
+	Type        string   `json:"type,omitempty"`
+	Default     string   `json:"default,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Flags       []string `json:"flags,omitempty"`
+	// Params tells what parameters a dispatcher takes
+	Params string `json:"params,omitempty"`
+	Link   string `json:"link,omitempty"`
+	// AddedIn and RemovedIn are the Hyprland versions a variable was added and removed in, if known
+	AddedIn   string `json:"addedIn,omitempty"`
+	RemovedIn string `json:"removedIn,omitempty"`
+	// Variables are the variables of a section
+	Variables []Doc `json:"variables,omitempty"`
+}
+
+// DispatcherDocPrefix is put before the names of dispatchers to look up their documentation, since some dispatchers have the same name as keywords, such as exec or workspace
+const DispatcherDocPrefix = "dispatcher:"
+
+// LookupDoc returns the documentation of the variable (e.g. decoration:blur:size), section (e.g. decoration:blur), keyword (e.g. bind) or dispatcher (e.g. dispatcher:movefocus) named query.
+// Dispatchers can also be looked up without the prefix, when no keyword has the same name.
+func LookupDoc(query string) (Doc, bool) {
+	if name, ok := strings.CutPrefix(query, DispatcherDocPrefix); ok {
+		if dispatcher, found := parser_data.FindDispatcher(name); found {
+			return dispatcherDoc(dispatcher), true
+		}
+		return Doc{}, false
+	}
+	if section, variable := parser_data.FindVariableByPath(query); variable != nil {
+		return variableDoc(*section, *variable), true
+	}
+	if section := parser_data.FindSectionByPath(query); section != nil {
+		return sectionDoc(*section), true
+	}
+	if keyword, found := parser_data.FindKeyword(query); found {
+		return keywordDoc(keyword), true
+	}
+	if dispatcher, found := parser_data.FindDispatcher(query); found {
+		return dispatcherDoc(dispatcher), true
+	}
+	return Doc{}, false
+}
+
+// SearchDocs returns the documentation of the variables, keywords and dispatchers that match query, best matches first
+func SearchDocs(query string) []Doc {
+	results := parser_data.Search(query)
+	docs := make([]Doc, 0, len(results))
+	for _, result := range results {
+		if result.Keyword != nil {
+			docs = append(docs, keywordDoc(*result.Keyword))
+			continue
+		}
+		if result.Dispatcher != nil {
+			docs = append(docs, dispatcherDoc(*result.Dispatcher))
+			continue
+		}
+		doc := variableDoc(parser_data.SectionDefinition{}, *result.Variable)
+		doc.Path = result.Path
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+func variableDoc(section parser_data.SectionDefinition, variable parser_data.VariableDefinition) Doc {
+	return Doc{
+		Kind:        "variable",
+		Path:        section.PathString() + parser_data.PathSeparator + variable.Name,
+		Type:        variable.Type,
+		Default:     variable.Default,
+		Description: variable.Description,
+		AddedIn:     variable.AddedIn,
+		RemovedIn:   variable.RemovedIn,
+	}
+}
+
+func sectionDoc(section parser_data.SectionDefinition) Doc {
+	doc := Doc{
+		Kind:      "section",
+		Path:      section.PathString(),
+		Variables: make([]Doc, 0, len(section.Variables)),
+	}
+	for _, variable := range section.Variables {
+		doc.Variables = append(doc.Variables, variableDoc(section, variable))
+	}
+	return doc
+}
+
+func keywordDoc(keyword parser_data.KeywordDefinition) Doc {
+	return Doc{
+		Kind:        "keyword",
+		Path:        keyword.Name,
+		Description: strings.TrimSpace(keyword.Description),
+		Flags:       keyword.Flags,
+		Link:        keyword.DocumentationLink(),
+	}
+}
+
+func dispatcherDoc(dispatcher parser_data.DispatcherDefinition) Doc {
+	return Doc{
+		Kind:        "dispatcher",
+		Path:        DispatcherDocPrefix + dispatcher.Name,
+		Description: dispatcher.Description,
+		Params:      dispatcher.Params,
+		Link:        dispatcher.DocumentationLink(),
+	}
+}
+
+// shortcodePattern matches the Hugo shortcodes of the wiki, such as {{< callout type=info >}}, which are meaningless outside of it
+var shortcodePattern = regexp.MustCompile(`(?m)^[ \t]*\{\{<.*>\}\}[ \t]*\n\n?`)
+
+// WriteDoc writes the documentation in a format suitable for a terminal
+func WriteDoc(w io.Writer, doc Doc) error {
+	var out strings.Builder
+	switch doc.Kind {
+	case "variable":
+		fmt.Fprintf(&out, "%s (%s)\n", doc.Path, doc.Type)
+		fmt.Fprintf(&out, "  Defaults to: %s\n", parser_data.VariableDefinition{Default: doc.Default}.PrettyDefault())
+		if doc.AddedIn != "" {
+			fmt.Fprintf(&out, "  Added in: %s\n", doc.AddedIn)
+		}
+		if doc.RemovedIn != "" {
+			fmt.Fprintf(&out, "  Removed in: %s\n", doc.RemovedIn)
+		}
+		if doc.Description != "" {
+			fmt.Fprintf(&out, "\n  %s\n", doc.Description)
+		}
+	case "section":
+		fmt.Fprintf(&out, "%s\n\n", doc.Path)
+		for _, variable := range doc.Variables {
+			fmt.Fprintf(&out, "  %s (%s, defaults to %s)\n", variable.Path, variable.Type, parser_data.VariableDefinition{Default: variable.Default}.PrettyDefault())
+			if variable.Description != "" {
+				fmt.Fprintf(&out, "      %s\n", variable.Description)
+			}
+		}
+	case "keyword":
+		fmt.Fprintf(&out, "%s\n", doc.Path)
+		if len(doc.Flags) > 0 {
+			fmt.Fprintf(&out, "  Accepts the following flags: %s\n", strings.Join(doc.Flags, ", "))
+		}
+		fmt.Fprintf(&out, "  See %s\n", doc.Link)
+		if doc.Description != "" {
+			fmt.Fprintf(&out, "\n%s\n", strings.TrimSpace(shortcodePattern.ReplaceAllString(doc.Description, "")))
+		}
+	case "dispatcher":
+		fmt.Fprintf(&out, "%s (dispatcher)\n", strings.TrimPrefix(doc.Path, DispatcherDocPrefix))
+		fmt.Fprintf(&out, "  Params: %s\n", doc.Params)
+		fmt.Fprintf(&out, "  See %s\n", doc.Link)
+		if doc.Description != "" {
+			fmt.Fprintf(&out, "\n  %s\n", doc.Description)
+		}
+	}
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// WriteDocsJSON writes the documentation as a JSON array of Doc
+func WriteDocsJSON(w io.Writer, docs []Doc) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(docs)
+}
@@ -0,0 +1,73 @@
+package hyprls
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ewen-lbh/hyprls/parser"
+)
+
+// EffectiveConfig evaluates the configuration whose main file is at path, with all the files it sources, and returns every option with the value it ends up with.
+// Assignments that cannot be decoded are ignored, hyprls check reports them.
+func EffectiveConfig(path string) ([]parser.EffectiveOption, error) {
+	graph, err := parser.LoadGraph(path, readFile)
+	if err != nil {
+		return nil, fmt.Errorf("while loading %s: %w", path, err)
+	}
+	options, _ := graph.EffectiveOptions()
+	return options, nil
+}
+
+// FilterEffectiveOptions returns the options whose path is one of paths, or is in one of the sections at paths. Paths are case-insensitive.
+func FilterEffectiveOptions(options []parser.EffectiveOption, paths []string) []parser.EffectiveOption {
+	filtered := make([]parser.EffectiveOption, 0)
+	for _, option := range options {
+		for _, path := range paths {
+			path = strings.ToLower(strings.Trim(path, ":"))
+			if option.Path == path || strings.HasPrefix(option.Path, path+":") {
+				filtered = append(filtered, option)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// WriteHumanEffectiveConfig writes the options as assignments, each followed by a comment that tells where its value comes from and which assignments it overrides:
+//
+//	general:gaps_out = 10  # hyprland.conf:12
+//	  # overrides 20 from conf.d/looks.conf:3
+func WriteHumanEffectiveConfig(w io.Writer, options []parser.EffectiveOption) error {
+	for _, option := range options {
+		origin := "default"
+		if option.SetBy != nil {
+			origin = assignmentLocation(*option.SetBy)
+			if option.IsDefault {
+				origin += ", same as default"
+			}
+		}
+		if _, err := fmt.Fprintf(w, "%s  # %s\n", strings.TrimSpace(option.Path+" = "+option.Value), origin); err != nil {
+			return err
+		}
+		for i := len(option.Shadowed) - 1; i >= 0; i-- {
+			shadowed := option.Shadowed[i]
+			if _, err := fmt.Fprintf(w, "  # overrides %s from %s\n", shadowed.Value, assignmentLocation(shadowed)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteJSONEffectiveConfig writes the options as a JSON array of parser.EffectiveOption
+func WriteJSONEffectiveConfig(w io.Writer, options []parser.EffectiveOption) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(options)
+}
+
+func assignmentLocation(assignment parser.OptionAssignment) string {
+	return fmt.Sprintf("%s:%d", displayPath(assignment.File), assignment.Position.Line+1)
+}
@@ -0,0 +1,47 @@
+package hyprls
+
+import (
+	"context"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/ewen-lbh/hyprls/parser"
+	"go.lsp.dev/protocol"
+)
+
+func (h Handler) Formatting(ctx context.Context, params *protocol.DocumentFormattingParams) ([]protocol.TextEdit, error) {
+	contents, err := h.state.file(params.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	formatted := parser.Format(contents, formatOptions(params.Options))
+	if formatted == contents {
+		return []protocol.TextEdit{}, nil
+	}
+
+	lines := strings.Split(contents, "\n")
+	return []protocol.TextEdit{{
+		Range: protocol.Range{
+			Start: protocol.Position{Line: 0, Character: 0},
+			End:   protocol.Position{Line: uint32(len(lines) - 1), Character: uint32(utf16Length(lines[len(lines)-1]))},
+		},
+		NewText: formatted,
+	}}, nil
+}
+
+// utf16Length returns the length of s in UTF-16 code units, which is how LSP positions count characters
+func utf16Length(s string) int {
+	return len(utf16.Encode([]rune(s)))
+}
+
+// formatOptions returns the formatter options that correspond to the client's formatting options
+func formatOptions(options protocol.FormattingOptions) parser.FormatOptions {
+	if !options.InsertSpaces {
+		return parser.FormatOptions{Indent: "\t"}
+	}
+	if options.TabSize == 0 {
+		return parser.FormatOptions{}
+	}
+	return parser.FormatOptions{Indent: strings.Repeat(" ", int(options.TabSize))}
+}
@@ -0,0 +1,28 @@
+package hyprls
+
+import (
+	"context"
+	"testing"
+
+	"go.lsp.dev/protocol"
+	"go.uber.org/zap"
+)
+
+func TestFormattingRangeCountsUTF16CodeUnits(t *testing.T) {
+	h := Handler{Logger: zap.NewNop(), state: newState()}
+	document := protocol.URI("file:///hypr/hyprland.conf")
+	h.state.open(document, "general {\ngaps_in=5\n}\nexec-once = notify-send \"héllo 🎉\"")
+
+	edits, err := h.Formatting(context.Background(), &protocol.DocumentFormattingParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: document},
+		Options:      protocol.FormattingOptions{TabSize: 4, InsertSpaces: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// "héllo" is 5 code units, and the emoji is a surrogate pair of 2
+	expected := protocol.Position{Line: 3, Character: uint32(len(`exec-once = notify-send "`) + 5 + 1 + 2 + 1)}
+	if len(edits) != 1 || edits[0].Range.End != expected {
+		t.Errorf("expected the edit to end at %+v, got %+v", expected, edits)
+	}
+}
@@ -0,0 +1,74 @@
+package hyprls
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+	"go.uber.org/zap"
+)
+
+// FuzzLanguageFeatures checks that the server answers requests on any document, at any position, without panicking
+func FuzzLanguageFeatures(f *testing.F) {
+	f.Setenv("HOME", "/fuzz")
+	f.Setenv("XDG_CONFIG_HOME", "/fuzz/.config")
+	fixtures, err := filepath.Glob(filepath.Join("parser", "fixtures", "*.hl"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, fixture := range fixtures {
+		contents, err := os.ReadFile(fixture)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(string(contents), uint32(40), uint32(12))
+	}
+	f.Add("general {\n    layout = \n}\n", uint32(1), uint32(13))
+	f.Add("bind = SUPER, Q, exec, kitty", uint32(0), uint32(0))
+	f.Add("", uint32(3), uint32(7))
+
+	f.Fuzz(func(t *testing.T, contents string, line uint32, character uint32) {
+		ctx := context.Background()
+		h := Handler{Logger: zap.NewNop(), state: newState()}
+		document := uri.File("/fuzz/.config/hypr/hyprland.conf")
+		h.state.open(document, contents)
+
+		identifier := protocol.TextDocumentIdentifier{URI: document}
+		at := protocol.TextDocumentPositionParams{TextDocument: identifier, Position: protocol.Position{Line: line, Character: character}}
+		h.Completion(ctx, &protocol.CompletionParams{TextDocumentPositionParams: at})
+		h.Hover(ctx, &protocol.HoverParams{TextDocumentPositionParams: at})
+		h.Definition(ctx, &protocol.DefinitionParams{TextDocumentPositionParams: at})
+		h.DocumentSymbol(ctx, &protocol.DocumentSymbolParams{TextDocument: identifier})
+		h.DocumentColor(ctx, &protocol.DocumentColorParams{TextDocument: identifier})
+		h.Formatting(ctx, &protocol.DocumentFormattingParams{TextDocument: identifier})
+		h.CodeAction(ctx, &protocol.CodeActionParams{
+			TextDocument: identifier,
+			Range:        protocol.Range{Start: at.Position, End: at.Position},
+		})
+	})
+}
+
+// FuzzColorEncoding checks that colors picked in the editor are written the same way once read back
+func FuzzColorEncoding(f *testing.F) {
+	f.Add(1.0, 0.0, 0.0, 1.0)
+	f.Add(0.2, 0.8, 1.0, 0.93)
+	f.Add(0.005, 0.5, 0.995, 0.0)
+
+	f.Fuzz(func(t *testing.T, red, green, blue, alpha float64) {
+		color := protocol.Color{Red: red, Green: green, Blue: blue, Alpha: alpha}
+		encoded := encodeColorLiteral(color)
+		for _, component := range []float64{red, green, blue, alpha} {
+			if math.IsNaN(component) || component < 0 || component > 1 {
+				return
+			}
+		}
+
+		if again := encodeColorLiteral(decodeColorLiteral(encoded)); again != encoded {
+			t.Errorf("%v was encoded to %s, but to %s once read back", color, encoded, again)
+		}
+	})
+}
@@ -10,23 +10,43 @@ import (
 type Handler struct {
 	protocol.Server
 	Logger *zap.Logger
+	// client is used to send notifications to the client, such as diagnostics
+	client protocol.Client
+	// state is not shared between handlers, so that every client connection gets its own
+	state *state
 }
 
-func NewHandler(ctx context.Context, server protocol.Server, logger *zap.Logger) (Handler, context.Context, error) {
-
+func NewHandler(ctx context.Context, server protocol.Server, client protocol.Client, handlerLogger *zap.Logger) (Handler, context.Context, error) {
 	return Handler{
 		Server: server,
-		Logger: logger,
-	}, context.WithValue(ctx, "state", state{}), nil
+		Logger: handlerLogger,
+		client: client,
+		state:  newState(),
+	}, ctx, nil
 }
 
 func (h Handler) Initialize(ctx context.Context, params *protocol.InitializeParams) (*protocol.InitializeResult, error) {
-	logger = h.Logger
+	if params.InitializationOptions != nil {
+		settings, err := parseSettings(params.InitializationOptions)
+		if err != nil {
+			return nil, err
+		}
+		h.state.configure(settings)
+	}
+
 	return &protocol.InitializeResult{
 		Capabilities: protocol.ServerCapabilities{
-			HoverProvider:          true,
-			DocumentSymbolProvider: true,
-			ColorProvider:          true,
+			HoverProvider:              true,
+			DocumentSymbolProvider:     true,
+			DefinitionProvider:         true,
+			DocumentFormattingProvider: true,
+			ColorProvider:              true,
+			CodeActionProvider: &protocol.CodeActionOptions{
+				CodeActionKinds: []protocol.CodeActionKind{protocol.QuickFix},
+			},
+			ExecuteCommandProvider: &protocol.ExecuteCommandOptions{
+				Commands: commandNames(),
+			},
 			CompletionProvider: &protocol.CompletionOptions{
 				ResolveProvider:   false,
 				TriggerCharacters: []string{},
@@ -43,6 +63,19 @@ func (h Handler) Initialize(ctx context.Context, params *protocol.InitializePara
 	}, nil
 }
 
+func (h Handler) DidChangeConfiguration(ctx context.Context, params *protocol.DidChangeConfigurationParams) error {
+	settings, err := parseSettings(params.Settings)
+	if err != nil {
+		return err
+	}
+	h.state.configure(settings)
+	// The target version changes what diagnostics are reported
+	for _, uri := range h.state.openedURIs() {
+		h.publishDiagnostics(ctx, uri)
+	}
+	return nil
+}
+
 func (h Handler) Initialized(ctx context.Context, params *protocol.InitializedParams) error {
 	return nil
 }
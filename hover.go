@@ -11,15 +11,25 @@ import (
 )
 
 func (h Handler) Hover(ctx context.Context, params *protocol.HoverParams) (*protocol.Hover, error) {
-	line, err := currentLine(params.TextDocument.URI, params.Position)
+	line, err := h.state.currentLine(params.TextDocument.URI, params.Position)
 	if err != nil {
 		return nil, fmt.Errorf("while getting current line of file: %w", err)
 	}
 
+	if directive, found := parser_data.FindHyprlangDirective(line); found {
+		return directiveHover(directive), nil
+	}
+
 	if !strings.Contains(line, "=") {
 		return nil, nil
 	}
 
+	if file, err := h.state.parse(params.TextDocument.URI); err == nil {
+		if hover := dispatcherHover(file, line, params.Position); hover != nil {
+			return hover, nil
+		}
+	}
+
 	// key is word before the equal sign. [0] is safe since we checked for "=" above
 	key := strings.TrimSpace(strings.Split(line, "=")[0])
 
@@ -30,6 +40,7 @@ func (h Handler) Hover(ctx context.Context, params *protocol.HoverParams) (*prot
 		return r != ' ' && r != '\t'
 	}) + 1
 
+	version := h.state.targetVersion(params.TextDocument.URI)
 	for _, section := range parser_data.Sections {
 		if def := section.VariableDefinition(key); def != nil {
 			return &protocol.Hover{
@@ -38,8 +49,8 @@ func (h Handler) Hover(ctx context.Context, params *protocol.HoverParams) (*prot
 					Value: heredoc.Docf(`### %s: %s (%s)
 						%s
 						
-						- Defaults to: %s
-					`, strings.Join(section.Path, ":"), def.Name, def.Type, def.Description, def.PrettyDefault()),
+						- Defaults to: %s%s
+					`, strings.Join(section.Path, ":"), def.Name, def.Type, def.Description, def.PrettyDefault(), versionHistory(*def, version)),
 				},
 				Range: &protocol.Range{
 					Start: protocol.Position{
@@ -78,3 +89,32 @@ func (h Handler) Hover(ctx context.Context, params *protocol.HoverParams) (*prot
 
 	return nil, nil
 }
+
+// directiveHover returns the hover of a comment such as # hyprlang noerror true
+func directiveHover(directive parser_data.KeywordDefinition) *protocol.Hover {
+	title := "### # hyprlang " + directive.Name
+	if directive.DocumentationFile != "" {
+		title += fmt.Sprintf(" [[docs]](%s)", directive.DocumentationLink())
+	}
+	return &protocol.Hover{
+		Contents: protocol.MarkupContent{
+			Kind:  protocol.Markdown,
+			Value: title + "\n" + directive.Description,
+		},
+	}
+}
+
+// versionHistory returns the lines of a hover that tell when the variable was added and removed, and whether it exists in the targeted Hyprland version
+func versionHistory(def parser_data.VariableDefinition, version string) string {
+	out := ""
+	if def.AddedIn != "" {
+		out += fmt.Sprintf("\n- Added in: %s", def.AddedIn)
+	}
+	if def.RemovedIn != "" {
+		out += fmt.Sprintf("\n- Removed in: %s", def.RemovedIn)
+	}
+	if !def.AvailableIn(version) {
+		out += fmt.Sprintf("\n\n**Not available in Hyprland %s**, which this configuration targets", version)
+	}
+	return out
+}
@@ -0,0 +1,47 @@
+// Package golden compares the output of tests to golden files, which are rewritten instead when tests are run with -update
+package golden
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ewen-lbh/hyprls/internal/textdiff"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files with the actual output of the tests")
+
+// Assert fails the test if actual is not the contents of the golden file at path, showing the difference between both.
+// With -update, it writes actual to path instead.
+func Assert(t testing.TB, path string, actual string) {
+	t.Helper()
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(actual), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("while reading the golden file: %s. Run the tests with -update to create it", err)
+	}
+	if diff := textdiff.Unified(path, "actual", string(expected), actual); diff != "" {
+		t.Errorf("output differs from %s, run the tests with -update to rewrite it if that is expected:\n%s", path, diff)
+	}
+}
+
+// AssertJSON is Assert with the indented JSON encoding of value
+func AssertJSON(t testing.TB, path string, value any) {
+	t.Helper()
+	encoded, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	Assert(t, path, string(encoded)+"\n")
+}
@@ -0,0 +1,150 @@
+// Package textdiff computes line-based unified diffs between two texts
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around changes
+const contextLines = 3
+
+type operation int
+
+const (
+	equal operation = iota
+	deletion
+	insertion
+)
+
+type edit struct {
+	op operation
+	// oldLine and newLine are the indices of the line in the old and new texts
+	oldLine, newLine int
+	text             string
+}
+
+// Unified returns the unified diff to go from before to after, using oldName and newName as the file names in the header.
+// It returns an empty string if both texts are equal.
+func Unified(oldName, newName, before, after string) string {
+	if before == after {
+		return ""
+	}
+
+	edits := diffLines(splitLines(before), splitLines(after))
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", oldName, newName)
+	for _, hunk := range hunks(edits) {
+		writeHunk(&out, hunk)
+	}
+	return out.String()
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines returns the edits to go from before to after, using the longest common subsequence of their lines
+func diffLines(before, after []string) []edit {
+	// lcs[i][j] is the length of the longest common subsequence of before[i:] and after[j:]
+	lcs := make([][]int, len(before)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	edits := make([]edit, 0, len(before)+len(after))
+	i, j := 0, 0
+	for i < len(before) || j < len(after) {
+		switch {
+		case i < len(before) && j < len(after) && before[i] == after[j]:
+			edits = append(edits, edit{equal, i, j, before[i]})
+			i++
+			j++
+		case j < len(after) && (i == len(before) || lcs[i][j+1] > lcs[i+1][j]):
+			edits = append(edits, edit{insertion, i, j, after[j]})
+			j++
+		default:
+			edits = append(edits, edit{deletion, i, j, before[i]})
+			i++
+		}
+	}
+	return edits
+}
+
+// hunks groups edits into hunks of changes, with up to contextLines unchanged lines around them
+func hunks(edits []edit) [][]edit {
+	result := make([][]edit, 0)
+	start, end := -1, -1
+	for index, e := range edits {
+		if e.op == equal {
+			continue
+		}
+		from := max(0, index-contextLines)
+		if start != -1 && from > end {
+			result = append(result, edits[start:end])
+			start = -1
+		}
+		if start == -1 {
+			start = from
+		}
+		end = min(len(edits), index+contextLines+1)
+	}
+	if start != -1 {
+		result = append(result, edits[start:end])
+	}
+	return result
+}
+
+func writeHunk(out *strings.Builder, hunk []edit) {
+	oldCount, newCount := 0, 0
+	for _, e := range hunk {
+		if e.op != insertion {
+			oldCount++
+		}
+		if e.op != deletion {
+			newCount++
+		}
+	}
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(hunk[0].oldLine, oldCount), hunkRange(hunk[0].newLine, newCount))
+
+	for _, e := range hunk {
+		prefix := " "
+		switch e.op {
+		case deletion:
+			prefix = "-"
+		case insertion:
+			prefix = "+"
+		}
+		out.WriteString(prefix + e.text)
+		if !strings.HasSuffix(e.text, "\n") {
+			out.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// hunkRange formats the line range of a hunk, where start is the 0-based index of its first line
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprint(start + 1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
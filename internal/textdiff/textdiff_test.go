@@ -0,0 +1,56 @@
+package textdiff
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+)
+
+func TestUnified(t *testing.T) {
+	before := heredoc.Doc(`
+		a
+		b
+		c
+		d
+		e
+		f
+		g
+		h
+		i
+	`)
+	after := heredoc.Doc(`
+		a
+		B
+		c
+		d
+		e
+		f
+		g
+		h
+		i
+		j
+	`)
+	expected := heredoc.Doc(`
+		--- before
+		+++ after
+		@@ -1,5 +1,5 @@
+		 a
+		-b
+		+B
+		 c
+		 d
+		 e
+		@@ -7,3 +7,4 @@
+		 g
+		 h
+		 i
+		+j
+	`)
+
+	if diff := Unified("before", "after", before, after); diff != expected {
+		t.Errorf("unexpected diff:\n%s\nexpected:\n%s", diff, expected)
+	}
+	if diff := Unified("before", "after", before, before); diff != "" {
+		t.Errorf("expected no diff for equal texts, got:\n%s", diff)
+	}
+}
@@ -0,0 +1,190 @@
+package hyprls
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ewen-lbh/hyprls/parser"
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+)
+
+// binding is a bind as it is evaluated in a configuration
+type binding struct {
+	parser.Bind
+	File   string
+	Submap string
+	// Modmask and NormalizedKey are the bind's key combination, after variable expansion and normalization
+	Modmask       []parser.ModKey
+	NormalizedKey string
+}
+
+func (b binding) combo() string {
+	return parser.Combo(b.Modmask, b.NormalizedKey)
+}
+
+func (b binding) location() protocol.Location {
+	return protocol.Location{
+		URI:   uri.File(b.File),
+		Range: b.Statement.LSPRange(),
+	}
+}
+
+// conflictKey returns a string that is the same for two binds that trigger at the same time.
+// Binds on key release, mouse binds and long press binds don't trigger at the same time as regular binds.
+func (b binding) conflictKey() string {
+	kind := ""
+	for _, flag := range "rmo" {
+		if b.HasFlag(flag) {
+			kind += string(flag)
+		}
+	}
+	return fmt.Sprintf("%s|%s|%s", b.Submap, kind, b.combo())
+}
+
+// keybindsReport is the result of analyzeKeybinds
+type keybindsReport struct {
+	// Conflicts are groups of binds that are active at the same time on the same key combination
+	Conflicts [][]binding
+	// UselessUnbinds are unbind statements that don't remove any bind
+	UselessUnbinds []binding
+}
+
+// analyzeKeybinds walks the configuration in the order Hyprland evaluates it, and reports binds that conflict with each other.
+// An unbind statement removes every bind on its key combination that was declared before it, so a bind declared after an unbind does not conflict with binds declared before it.
+func analyzeKeybinds(graph *parser.Graph) keybindsReport {
+	report := keybindsReport{}
+	variables := make(map[string]string)
+	active := make(map[string][]binding)
+	conflicting := make(map[string][]binding)
+	conflictsOrder := make([]string, 0)
+	submap := ""
+
+	graph.Walk(func(entry parser.Entry) {
+		if entry.Variable != nil {
+			variables[entry.Variable.Key] = parser.ExpandVariables(entry.Variable.ValueRaw, variables)
+			return
+		}
+		if entry.Statement == nil {
+			return
+		}
+
+		stmt := *entry.Statement
+		switch {
+		case stmt.Keyword == "submap":
+			submap = parser.SubmapName(stmt)
+			if submap == parser.SubmapReset {
+				submap = ""
+			}
+
+		case stmt.Keyword == "unbind":
+			unbind, err := parser.ParseUnbind(stmt)
+			if err != nil {
+				return
+			}
+			removed := newBinding(unbind, entry.File.Path, submap, variables)
+			found := false
+			for key, bindings := range active {
+				remaining := make([]binding, 0, len(bindings))
+				for _, b := range bindings {
+					if b.combo() == removed.combo() {
+						found = true
+					} else {
+						remaining = append(remaining, b)
+					}
+				}
+				active[key] = remaining
+			}
+			if !found {
+				report.UselessUnbinds = append(report.UselessUnbinds, removed)
+			}
+
+		case stmt.IsBind():
+			bind, err := parser.ParseBind(stmt)
+			if err != nil {
+				return
+			}
+			b := newBinding(bind, entry.File.Path, submap, variables)
+			key := b.conflictKey()
+			active[key] = append(active[key], b)
+			if len(active[key]) < 2 {
+				return
+			}
+			if _, ok := conflicting[key]; !ok {
+				conflictsOrder = append(conflictsOrder, key)
+			}
+			conflicting[key] = append([]binding{}, active[key]...)
+		}
+	})
+
+	for _, key := range conflictsOrder {
+		report.Conflicts = append(report.Conflicts, conflicting[key])
+	}
+	return report
+}
+
+func newBinding(bind parser.Bind, file string, submap string, variables map[string]string) binding {
+	mods := parser.ExpandVariables(bind.Mods, variables)
+	modmask, err := parser.NormalizeModmask(mods)
+	if err != nil {
+		// Keep unknown mod keys around so that they still take part in the comparison
+		modmask = nil
+		mods = strings.ToUpper(mods)
+	} else {
+		mods = ""
+	}
+	return binding{
+		Bind:          bind,
+		File:          file,
+		Submap:        submap,
+		Modmask:       modmask,
+		NormalizedKey: strings.TrimSpace(mods + " " + parser.NormalizeKey(parser.ExpandVariables(bind.Key, variables))),
+	}
+}
+
+func checkBindConflicts(ctx diagnosticContext) []protocol.Diagnostic {
+	diagnostics := make([]protocol.Diagnostic, 0)
+	for _, group := range ctx.Keybinds.Conflicts {
+		for i, b := range group {
+			if b.File != ctx.File.Path {
+				continue
+			}
+
+			related := make([]protocol.DiagnosticRelatedInformation, 0, len(group)-1)
+			for j, other := range group {
+				if i == j {
+					continue
+				}
+				related = append(related, protocol.DiagnosticRelatedInformation{
+					Location: other.location(),
+					Message:  fmt.Sprintf("%s is also bound here", other.combo()),
+				})
+			}
+
+			where := ""
+			if b.Submap != "" {
+				where = fmt.Sprintf(" in submap %q", b.Submap)
+			}
+			diagnostics = append(diagnostics, protocol.Diagnostic{
+				Range:              b.Statement.LSPRange(),
+				Message:            fmt.Sprintf("%s is bound %d times%s", b.combo(), len(group), where),
+				RelatedInformation: related,
+			})
+		}
+	}
+	return diagnostics
+}
+
+func checkUselessUnbinds(ctx diagnosticContext) []protocol.Diagnostic {
+	diagnostics := make([]protocol.Diagnostic, 0)
+	for _, unbind := range ctx.Keybinds.UselessUnbinds {
+		if unbind.File != ctx.File.Path {
+			continue
+		}
+		diagnostics = append(diagnostics, protocol.Diagnostic{
+			Range:   unbind.Statement.LSPRange(),
+			Message: fmt.Sprintf("%s is not bound before this line, so unbinding it does nothing", unbind.combo()),
+		})
+	}
+	return diagnostics
+}
@@ -2,9 +2,14 @@ package hyprls
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"net"
 	"os"
 	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
 
 	"go.lsp.dev/jsonrpc2"
 	"go.lsp.dev/protocol"
@@ -12,54 +17,173 @@ import (
 	"go.uber.org/zap"
 )
 
+// Version is set at link time with -ldflags "-X github.com/ewen-lbh/hyprls.Version=...".
+// When it isn't, the version of the module is used, which is set when installing with go install.
 var Version string
 
-func StartServer(logger *zap.Logger, logClientIn string) {
+func init() {
+	if Version != "" {
+		return
+	}
+	Version = "(devel)"
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		Version = info.Main.Version
+	}
+}
+
+// StartServer serves the language server over stdin and stdout.
+// If traceRPCIn is not empty, all requests and responses are appended to files in that directory.
+func StartServer(logger *zap.Logger, traceRPCIn string) {
 	logger.Debug("starting server")
-	conn := jsonrpc2.NewConn(jsonrpc2.NewStream(&readWriteCloser{
+	stream := &readWriteCloser{
 		reader: os.Stdin,
 		writer: os.Stdout,
-		logAt:  logClientIn,
-	}))
-	handler, ctx, err := NewHandler(context.Background(), protocol.ServerDispatcher(conn, logger), logger)
+	}
+	if traceRPCIn != "" {
+		if err := stream.traceTo(traceRPCIn); err != nil {
+			logger.Sugar().Fatalf("while opening RPC trace files: %s", err)
+		}
+	}
+
+	if err := ServeConnection(context.Background(), stream, logger); err != nil {
+		logger.Sugar().Fatalf("while serving: %s", err)
+	}
+}
+
+// ServeConnection serves the language server on a single client connection, until the connection is closed or ctx is cancelled.
+// Every connection gets its own handler, with its own state.
+func ServeConnection(ctx context.Context, stream io.ReadWriteCloser, logger *zap.Logger) error {
+	conn := jsonrpc2.NewConn(jsonrpc2.NewStream(stream))
+	handler, ctx, err := NewHandler(ctx, protocol.ServerDispatcher(conn, logger), protocol.ClientDispatcher(conn, logger), logger)
 	if err != nil {
-		logger.Sugar().Fatalf("while initializing handler: %w", err)
+		return fmt.Errorf("while initializing handler: %w", err)
 	}
 
 	conn.Go(ctx, protocol.ServerHandler(handler, jsonrpc2.MethodNotFoundHandler))
-	<-conn.Done()
+	select {
+	case <-conn.Done():
+	case <-ctx.Done():
+		conn.Close()
+		<-conn.Done()
+	}
+	return nil
+}
+
+// Listen starts listening for client connections on address, which is of the form tcp:host:port or unix:/path/to/socket.
+func Listen(address string) (net.Listener, error) {
+	network, addr, ok := strings.Cut(address, ":")
+	if !ok || (network != "tcp" && network != "unix") || addr == "" {
+		return nil, fmt.Errorf("invalid address %q, use tcp:host:port or unix:/path/to/socket", address)
+	}
+
+	if network == "unix" {
+		removeStaleSocket(addr)
+	}
+	return net.Listen(network, addr)
+}
+
+// removeStaleSocket removes the unix socket at path if no server is listening on it anymore, e.g. after a crash
+func removeStaleSocket(path string) {
+	info, err := os.Stat(path)
+	if err != nil || info.Mode()&os.ModeSocket == 0 {
+		return
+	}
+	if conn, err := net.Dial("unix", path); err == nil {
+		conn.Close()
+		return
+	}
+	os.Remove(path)
+}
+
+// Serve accepts client connections on listener and serves them concurrently, until ctx is cancelled.
+// If traceRPCIn is not empty, requests and responses of every connection are appended to files in a subdirectory of it.
+func Serve(ctx context.Context, listener net.Listener, logger *zap.Logger, traceRPCIn string) error {
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	var connections sync.WaitGroup
+	defer connections.Wait()
+	for id := 1; ; id++ {
+		netConn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("while accepting connection: %w", err)
+		}
+
+		connLogger := logger.With(zap.Int("connection", id))
+		connLogger.Info("client connected", zap.Stringer("from", netConn.RemoteAddr()))
+		stream := &readWriteCloser{reader: netConn, writer: netConn}
+		if traceRPCIn != "" {
+			dir := filepath.Join(traceRPCIn, fmt.Sprintf("connection-%d", id))
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				connLogger.Error("could not create RPC trace directory", zap.Error(err))
+			} else if err := stream.traceTo(dir); err != nil {
+				connLogger.Error("could not open RPC trace files", zap.Error(err))
+			}
+		}
+
+		connections.Add(1)
+		go func() {
+			defer connections.Done()
+			if err := ServeConnection(ctx, stream, connLogger); err != nil {
+				connLogger.Error("while serving", zap.Error(err))
+			}
+			connLogger.Info("client disconnected")
+		}()
+	}
 }
 
 type readWriteCloser struct {
 	reader io.ReadCloser
 	writer io.WriteCloser
-	logAt  string
+	// readTrace and writeTrace receive a copy of everything that is read and written, if they are not nil
+	readTrace  io.WriteCloser
+	writeTrace io.WriteCloser
 }
 
-func (r *readWriteCloser) Read(b []byte) (int, error) {
-	var f *os.File
-	if r.logAt != "" {
-		f, _ = os.OpenFile(filepath.Join(r.logAt, "client-request-from.log"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+// traceTo makes r append everything it reads and writes to files in directory
+func (r *readWriteCloser) traceTo(directory string) (err error) {
+	r.readTrace, err = os.OpenFile(filepath.Join(directory, "client-request-from.log"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
 	}
+	r.writeTrace, err = os.OpenFile(filepath.Join(directory, "client-response-to.log"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	return err
+}
+
+func (r *readWriteCloser) Read(b []byte) (int, error) {
 	n, err := r.reader.Read(b)
-	if r.logAt != "" {
+	if r.readTrace != nil {
 		if err != nil {
-			f.Write([]byte(err.Error() + "\n"))
+			r.readTrace.Write([]byte(err.Error() + "\n"))
 		} else {
-			f.Write(b)
+			r.readTrace.Write(b[:n])
 		}
 	}
 	return n, err
 }
 
 func (r *readWriteCloser) Write(b []byte) (int, error) {
-	if r.logAt != "" {
-		f, _ := os.OpenFile(filepath.Join(r.logAt, "client-response-to.log"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-		f.Write(b)
+	if r.writeTrace != nil {
+		r.writeTrace.Write(b)
 	}
 	return r.writer.Write(b)
 }
 
+// Close closes the reader and the writer, only once if they are the same, such as a network connection
 func (r *readWriteCloser) Close() error {
-	return multierr.Append(r.reader.Close(), r.writer.Close())
+	err := r.reader.Close()
+	if any(r.writer) != any(r.reader) {
+		err = multierr.Append(err, r.writer.Close())
+	}
+	for _, trace := range []io.Closer{r.readTrace, r.writeTrace} {
+		if trace != nil {
+			err = multierr.Append(err, trace.Close())
+		}
+	}
+	return err
 }
@@ -0,0 +1,123 @@
+package hyprls
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.lsp.dev/jsonrpc2"
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestServeKeepsConnectionsSeparate(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	listener, err := Listen("unix:" + filepath.Join(t.TempDir(), "hyprls.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	core, logs := observer.New(zap.DebugLevel)
+	served := make(chan error)
+	go func() {
+		served <- Serve(ctx, listener, zap.New(core), "")
+	}()
+
+	document := uri.File("/tmp/hyprland.conf")
+	connect := func(contents string) jsonrpc2.Conn {
+		netConn, err := net.Dial("unix", listener.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		conn := jsonrpc2.NewConn(jsonrpc2.NewStream(netConn))
+		conn.Go(ctx, func(ctx context.Context, reply jsonrpc2.Replier, req jsonrpc2.Request) error {
+			return reply(ctx, nil, nil)
+		})
+		if _, err := conn.Call(ctx, protocol.MethodInitialize, &protocol.InitializeParams{}, &protocol.InitializeResult{}); err != nil {
+			t.Fatal(err)
+		}
+		err = conn.Notify(ctx, protocol.MethodTextDocumentDidOpen, &protocol.DidOpenTextDocumentParams{
+			TextDocument: protocol.TextDocumentItem{URI: document, Text: contents},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = conn.Notify(ctx, protocol.MethodTextDocumentDidChange, &protocol.DidChangeTextDocumentParams{
+			TextDocument:   protocol.VersionedTextDocumentIdentifier{TextDocumentIdentifier: protocol.TextDocumentIdentifier{URI: document}, Version: 2},
+			ContentChanges: []protocol.TextDocumentContentChangeEvent{{Text: contents}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return conn
+	}
+
+	first := connect("$first = 1")
+	second := connect("$second = 2")
+
+	for conn, expected := range map[jsonrpc2.Conn]string{first: "$first", second: "$second"} {
+		var symbols []protocol.DocumentSymbol
+		_, err := conn.Call(ctx, protocol.MethodTextDocumentDocumentSymbol, &protocol.DocumentSymbolParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: document},
+		}, &symbols)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(symbols) != 1 || symbols[0].Name != expected {
+			t.Errorf("expected the connection to only see its own document with %s, got %#v", expected, symbols)
+		}
+		conn.Close()
+	}
+
+	cancel()
+	if err := <-served; err != nil {
+		t.Errorf("Serve returned an error: %s", err)
+	}
+
+	// Each connection logs with its own logger
+	connections := make(map[int64]bool)
+	for _, entry := range logs.FilterMessage("LSP:DidChange").All() {
+		if id, ok := entry.ContextMap()["connection"].(int64); ok {
+			connections[id] = true
+		}
+	}
+	if !connections[1] || !connections[2] {
+		t.Errorf("expected both connections to log their changes with their own logger, got logs of connections %v", connections)
+	}
+}
+
+// closeOnce is a connection that, like a net.TCPConn, returns an error when it is closed twice
+type closeOnce struct {
+	net.Conn
+	closed bool
+}
+
+func (c *closeOnce) Close() error {
+	if c.closed {
+		return net.ErrClosed
+	}
+	c.closed = true
+	return nil
+}
+
+func TestStreamClosesConnectionsOnce(t *testing.T) {
+	conn := &closeOnce{}
+	stream := &readWriteCloser{reader: conn, writer: conn}
+	if err := stream.Close(); err != nil {
+		t.Errorf("expected the connection to be closed once without errors, got %s", err)
+	}
+}
+
+func TestListenRejectsInvalidAddresses(t *testing.T) {
+	for _, address := range []string{"127.0.0.1:7777", "udp:127.0.0.1:7777", "unix:"} {
+		if _, err := Listen(address); err == nil {
+			t.Errorf("expected an error for %q", address)
+		}
+	}
+}
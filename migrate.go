@@ -0,0 +1,81 @@
+package hyprls
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ewen-lbh/hyprls/parser"
+	"go.lsp.dev/protocol"
+)
+
+// MigratedFile is a configuration file that has deprecated options or statements, before and after migrating them
+type MigratedFile struct {
+	Path   string
+	Before string
+	After  string
+	// Manual are the deprecations that are left as they are, because they must be migrated by hand
+	Manual []parser.Deprecation
+}
+
+// Migrate migrates the deprecated options and statements of the configuration at path, and of every file it sources, to the Hyprland version given, see parser.ConfigFile.Deprecations.
+// An empty version stands for the latest one. Only the files that change or that have deprecations to migrate by hand are returned, sorted by path.
+func Migrate(path string, version string) ([]MigratedFile, error) {
+	graph, err := parser.LoadGraph(path, readFile)
+	if err != nil {
+		return nil, fmt.Errorf("while loading %s: %w", path, err)
+	}
+
+	migrated := make([]MigratedFile, 0)
+	for _, file := range graph.SortedFiles() {
+		manual := make([]parser.Deprecation, 0)
+		for _, deprecation := range file.Deprecations(version) {
+			if deprecation.Manual {
+				manual = append(manual, deprecation)
+			}
+		}
+		if after := file.Migrate(version); after != file.Contents || len(manual) > 0 {
+			migrated = append(migrated, MigratedFile{Path: file.Path, Before: file.Contents, After: after, Manual: manual})
+		}
+	}
+	return migrated, nil
+}
+
+// CodeAction suggests quick fixes that migrate the deprecated options and statements in the requested range
+func (h Handler) CodeAction(ctx context.Context, params *protocol.CodeActionParams) ([]protocol.CodeAction, error) {
+	contents, err := h.state.file(params.TextDocument.URI)
+	if err != nil {
+		return nil, fmt.Errorf("while reading file: %w", err)
+	}
+	document, err := parser.Parse(contents)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing: %w", err)
+	}
+	file := &parser.ConfigFile{Path: params.TextDocument.URI.Filename(), Contents: contents, Document: document}
+
+	actions := make([]protocol.CodeAction, 0)
+	for _, deprecation := range file.Deprecations(h.state.targetVersion(params.TextDocument.URI)) {
+		line := deprecation.Range.Start.Line
+		if deprecation.Manual || line < params.Range.Start.Line || line > params.Range.End.Line {
+			continue
+		}
+
+		fixed := make([]protocol.Diagnostic, 0)
+		for _, diag := range params.Context.Diagnostics {
+			if diag.Range.Start.Line == line && (diag.Code == "deprecated" || diag.Code == "option-version") {
+				fixed = append(fixed, diag)
+			}
+		}
+		actions = append(actions, protocol.CodeAction{
+			Title:       deprecation.Title(),
+			Kind:        protocol.QuickFix,
+			Diagnostics: fixed,
+			IsPreferred: true,
+			Edit: &protocol.WorkspaceEdit{
+				Changes: map[protocol.DocumentURI][]protocol.TextEdit{
+					params.TextDocument.URI: deprecation.Edits,
+				},
+			},
+		})
+	}
+	return actions, nil
+}
@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Animation is an animation declared with the animation keyword.
+// Reference: https://wiki.hyprland.org/Configuring/Animations/
+type Animation struct {
+	// Name is the name of the animated element, e.g. windows or workspacesIn
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	// Speed is the duration of the animation, in deciseconds. It is only set for enabled animations.
+	Speed float32 `json:"speed,omitempty"`
+	// Curve is the name of the bezier curve to use, or default
+	Curve     string    `json:"curve,omitempty"`
+	Style     string    `json:"style,omitempty"`
+	Statement Statement `json:"-"`
+}
+
+// ParseAnimation decodes the arguments of an animation statement
+func ParseAnimation(stmt Statement) (Animation, error) {
+	if stmt.Keyword != "animation" {
+		return Animation{}, fmt.Errorf("%s is not an animation statement", stmt.Keyword)
+	}
+
+	args := stmt.RawArguments()
+	if len(args) < 2 {
+		return Animation{}, fmt.Errorf("animation needs at least 2 arguments, got %d", len(args))
+	}
+
+	enabled, err := parseBool(args[1])
+	if err != nil {
+		return Animation{}, fmt.Errorf("invalid onoff value %q: %w", args[1], err)
+	}
+	animation := Animation{Name: args[0], Enabled: enabled, Statement: stmt}
+	if len(args) > 2 {
+		speed, err := strconv.ParseFloat(args[2], 32)
+		if err != nil {
+			return Animation{}, fmt.Errorf("invalid speed %q: %w", args[2], err)
+		}
+		animation.Speed = float32(speed)
+	}
+	if len(args) > 3 {
+		animation.Curve = args[3]
+	}
+	if len(args) > 4 {
+		animation.Style = args[4]
+	}
+	return animation, nil
+}
+
+// Bezier is a curve declared with the bezier keyword, to be used by animations.
+// Reference: https://wiki.hyprland.org/Configuring/Animations/#curves
+type Bezier struct {
+	Name string `json:"name"`
+	// Points are the coordinates of the two control points: X0, Y0, X1, Y1
+	Points    [4]float32 `json:"points"`
+	Statement Statement  `json:"-"`
+}
+
+// ParseBezier decodes the arguments of a bezier statement
+func ParseBezier(stmt Statement) (Bezier, error) {
+	if stmt.Keyword != "bezier" {
+		return Bezier{}, fmt.Errorf("%s is not a bezier statement", stmt.Keyword)
+	}
+
+	args := stmt.RawArguments()
+	if len(args) != 5 {
+		return Bezier{}, fmt.Errorf("bezier needs 5 arguments, got %d", len(args))
+	}
+
+	bezier := Bezier{Name: args[0], Statement: stmt}
+	for i, arg := range args[1:] {
+		point, err := strconv.ParseFloat(arg, 32)
+		if err != nil {
+			return Bezier{}, fmt.Errorf("invalid point %q: %w", arg, err)
+		}
+		bezier.Points[i] = float32(point)
+	}
+	return bezier, nil
+}
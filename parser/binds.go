@@ -0,0 +1,213 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Bind is a keybind declared with one of the bind[flags] keywords.
+// Reference: https://wiki.hyprland.org/Configuring/Binds/
+type Bind struct {
+	// Flags are the letters that follow "bind" in the keyword, e.g. "el" for binde
+	Flags string `json:"flags,omitempty"`
+	// Mods is the modmask, as written in the source (it can contain custom variables)
+	Mods string `json:"mods"`
+	Key  string `json:"key"`
+	// Description is only set for binds that have the "d" flag
+	Description string `json:"description,omitempty"`
+	Dispatcher  string `json:"dispatcher"`
+	Params      string `json:"params,omitempty"`
+	// Submap is the name of the submap the bind is declared in, or empty for global binds. It is only set by Decode.
+	Submap    string    `json:"submap,omitempty"`
+	Statement Statement `json:"-"`
+}
+
+// HasFlag returns true if the bind was declared with the given flag, e.g. 'r' for bindr
+func (b Bind) HasFlag(flag rune) bool {
+	return strings.ContainsRune(b.Flags, flag)
+}
+
+// IsBind returns true if the statement declares a keybind (but not if it removes one with unbind)
+func (s Statement) IsBind() bool {
+	return strings.HasPrefix(string(s.Keyword), "bind")
+}
+
+// ParseBind decodes the arguments of a bind statement.
+// The parameters of the dispatcher are kept as-is, even if they contain commas.
+func ParseBind(stmt Statement) (Bind, error) {
+	if !stmt.IsBind() {
+		return Bind{}, fmt.Errorf("%s is not a bind keyword", stmt.Keyword)
+	}
+
+	bind := Bind{
+		Flags:     strings.TrimPrefix(string(stmt.Keyword), "bind"),
+		Statement: stmt,
+	}
+
+	argsCount := 4
+	if bind.HasFlag('d') {
+		argsCount = 5
+	}
+
+	args := strings.SplitN(stmt.ValueRaw, ",", argsCount)
+	for i, arg := range args {
+		args[i] = strings.TrimSpace(arg)
+	}
+
+	if len(args) < argsCount-1 {
+		return bind, fmt.Errorf("%s needs at least %d arguments, got %d", stmt.Keyword, argsCount-1, len(args))
+	}
+
+	bind.Mods, bind.Key = args[0], args[1]
+	args = args[2:]
+	if bind.HasFlag('d') {
+		bind.Description, args = args[0], args[1:]
+	}
+	bind.Dispatcher = args[0]
+	if len(args) > 1 {
+		bind.Params = args[1]
+	}
+	return bind, nil
+}
+
+// ParseUnbind decodes the arguments of an unbind statement. Only the Mods and Key of the returned bind are set.
+func ParseUnbind(stmt Statement) (Bind, error) {
+	if stmt.Keyword != "unbind" {
+		return Bind{}, fmt.Errorf("%s is not an unbind statement", stmt.Keyword)
+	}
+
+	args := stmt.RawArguments()
+	if len(args) < 2 {
+		return Bind{}, fmt.Errorf("unbind needs 2 arguments, got %d", len(args))
+	}
+	return Bind{Mods: args[0], Key: args[1], Statement: stmt}, nil
+}
+
+var modKeyCanonicalNames = map[ModKey]string{
+	ModShift:   "SHIFT",
+	ModCaps:    "CAPS",
+	ModControl: "CTRL",
+	ModAlt:     "ALT",
+	Mod2:       "MOD2",
+	Mod3:       "MOD3",
+	ModSuper:   "SUPER",
+	Mod5:       "MOD5",
+}
+
+func (k ModKey) String() string {
+	if name, ok := modKeyCanonicalNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("ModKey(%d)", int(k))
+}
+
+// NormalizeModmask parses a modmask written in any of the forms Hyprland accepts (e.g. "SUPER SHIFT", "super_shift", "SUPERSHIFT" or "WIN CONTROL").
+// The mod keys are returned sorted and without duplicates, so that two equivalent modmasks give the same result.
+func NormalizeModmask(raw string) ([]ModKey, error) {
+	present := make(map[ModKey]bool)
+	for _, token := range ModMaskSeparator.Split(strings.ToUpper(raw), -1) {
+		for token != "" {
+			longest := ""
+			for name := range ModKeyNames {
+				if strings.HasPrefix(token, name) && len(name) > len(longest) {
+					longest = name
+				}
+			}
+			if longest == "" {
+				return nil, fmt.Errorf("invalid mod key: %s", token)
+			}
+			present[ModKeyNames[longest]] = true
+			token = strings.TrimPrefix(token, longest)
+		}
+	}
+
+	mods := make([]ModKey, 0, len(present))
+	for key := ModShift; key <= Mod5; key++ {
+		if present[key] {
+			mods = append(mods, key)
+		}
+	}
+	return mods, nil
+}
+
+// keysymAliases maps the other names of keysyms that have several to the one NormalizeKey uses, in lowercase
+var keysymAliases = map[string]string{
+	"prior":            "page_up",
+	"next":             "page_down",
+	"kp_prior":         "kp_page_up",
+	"kp_next":          "kp_page_down",
+	"quoteright":       "apostrophe",
+	"quoteleft":        "grave",
+	"henkan":           "henkan_mode",
+	"script_switch":    "mode_switch",
+	"iso_group_shift":  "mode_switch",
+	"kana_switch":      "mode_switch",
+	"arabic_switch":    "mode_switch",
+	"greek_switch":     "mode_switch",
+	"hebrew_switch":    "mode_switch",
+	"hangul_switch":    "mode_switch",
+	"dead_perispomeni": "dead_tilde",
+	"dead_psili":       "dead_abovecomma",
+	"dead_dasia":       "dead_abovereversedcomma",
+}
+
+// asciiKeysyms are the names of the keysyms of the printable ASCII characters, whose values are their code points, from space (0x20) to asciitilde (0x7e).
+// Letters are in lowercase, since Hyprland looks keysyms up case-insensitively.
+var asciiKeysyms = strings.Fields(`
+	space exclam quotedbl numbersign dollar percent ampersand apostrophe parenleft parenright asterisk plus comma minus period slash
+	0 1 2 3 4 5 6 7 8 9 colon semicolon less equal greater question
+	at a b c d e f g h i j k l m n o p q r s t u v w x y z bracketleft backslash bracketright asciicircum underscore
+	grave a b c d e f g h i j k l m n o p q r s t u v w x y z braceleft bar braceright asciitilde
+`)
+
+// NormalizeKey returns a canonical name for a bind's key, so that two ways of writing the same key give the same name.
+// Like Hyprland, which resolves keys with xkb_keysym_from_name, keysym names are case-insensitive, and some keysyms have several names (Prior and Page_Up).
+// Keysyms of ASCII characters can also be given by value (0x71 or U0071 for q).
+// Keycodes (code:24) and mouse buttons (mouse:272) are only normalized among themselves: which keysym a keycode gives depends on the keyboard layout.
+func NormalizeKey(key string) string {
+	key = strings.ToLower(strings.TrimSpace(key))
+	if alias, ok := keysymAliases[key]; ok {
+		return alias
+	}
+
+	for _, prefix := range []string{"code:", "mouse:"} {
+		if number, ok := strings.CutPrefix(key, prefix); ok {
+			if n, err := strconv.Atoi(strings.TrimSpace(number)); err == nil {
+				return prefix + strconv.Itoa(n)
+			}
+			return key
+		}
+	}
+
+	for _, prefix := range []string{"0x", "u"} {
+		if hex, ok := strings.CutPrefix(key, prefix); ok {
+			if value, err := strconv.ParseUint(hex, 16, 32); err == nil && value >= 0x20 && value <= 0x7e {
+				return asciiKeysyms[value-0x20]
+			}
+		}
+	}
+	return key
+}
+
+// Combo returns a canonical, human-readable representation of a key combination, e.g. "SUPER SHIFT + q"
+func Combo(mods []ModKey, key string) string {
+	if len(mods) == 0 {
+		return key
+	}
+	names := make([]string, 0, len(mods))
+	for _, mod := range mods {
+		names = append(names, mod.String())
+	}
+	return strings.Join(names, " ") + " + " + key
+}
+
+// NormalizedCombo returns the bind's key combination, normalized so that binds on the same keys have the same combo.
+// Mods that are not valid mod keys are kept as-is, in uppercase, in front of the key.
+func (b Bind) NormalizedCombo() string {
+	modmask, err := NormalizeModmask(b.Mods)
+	if err != nil {
+		return strings.TrimSpace(strings.ToUpper(b.Mods) + " " + NormalizeKey(b.Key))
+	}
+	return Combo(modmask, NormalizeKey(b.Key))
+}
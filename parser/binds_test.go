@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseBindWithDescription(t *testing.T) {
+	bind, err := ParseBind(Statement{Keyword: "bindde", ValueRaw: "SUPER, Q, Close the window, killactive,"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bind.Description != "Close the window" || bind.Dispatcher != "killactive" || !bind.HasFlag('e') {
+		t.Errorf("unexpected bind: %#v", bind)
+	}
+
+	if _, err := ParseBind(Statement{Keyword: "bind", ValueRaw: "SUPER"}); err == nil {
+		t.Errorf("expected an error for a bind without a key")
+	}
+}
+
+func TestNormalizeModmask(t *testing.T) {
+	for _, raw := range []string{"SUPER SHIFT", "shift_super", "SUPERSHIFT", "WIN+SHIFT", "MOD4 SHIFT SUPER"} {
+		mods, err := NormalizeModmask(raw)
+		if err != nil {
+			t.Errorf("%q: %s", raw, err)
+			continue
+		}
+		if fmt.Sprint(mods) != "[SHIFT SUPER]" {
+			t.Errorf("%q: expected [SHIFT SUPER], got %v", raw, mods)
+		}
+	}
+
+	if mods, err := NormalizeModmask(""); err != nil || len(mods) != 0 {
+		t.Errorf("expected an empty modmask to be valid, got %v, %v", mods, err)
+	}
+
+	if _, err := NormalizeModmask("SUPER HYPER"); err == nil {
+		t.Errorf("expected an error for an unknown mod key")
+	}
+}
+
+func TestNormalizeKey(t *testing.T) {
+	for expected, keys := range map[string][]string{
+		"q":                    {"q", "Q", " q ", "0x71", "0x0071", "U0071", "u71"},
+		"return":               {"Return", "RETURN"},
+		"page_up":              {"Prior", "Page_Up", "page_up"},
+		"apostrophe":           {"apostrophe", "quoteright", "0x27"},
+		"code:24":              {"code:24", "CODE:024"},
+		"mouse:272":            {"mouse:272"},
+		"u":                    {"u", "U"},
+		"up":                   {"Up"},
+		"xf86audioraisevolume": {"XF86AudioRaiseVolume"},
+	} {
+		for _, key := range keys {
+			if normalized := NormalizeKey(key); normalized != expected {
+				t.Errorf("%q: expected %q, got %q", key, expected, normalized)
+			}
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package parser_data
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CatalogFormatVersion is the version of the format of Catalog.
+// It is incremented whenever a change could break programs that read catalogs, such as removing or renaming a field.
+// Version 2 gives the allowed values of integer options as a range instead of an enum.
+const CatalogFormatVersion = 2
+
+// Catalog is a machine-readable description of every section, variable and keyword of the configuration,
+// meant to be consumed by other tools so that they don't have to scrape the wiki themselves.
+type Catalog struct {
+	FormatVersion int `json:"formatVersion"`
+	// HyprlandVersions are the Hyprland versions the catalog knows about, oldest first
+	HyprlandVersions []string         `json:"hyprlandVersions"`
+	Sections         []CatalogSection `json:"sections"`
+	Keywords         []CatalogKeyword `json:"keywords"`
+}
+
+type CatalogSection struct {
+	// Path is the path of the section, e.g. decoration:blur
+	Path    string          `json:"path"`
+	Options []CatalogOption `json:"options"`
+}
+
+type CatalogOption struct {
+	Name string `json:"name"`
+	// Path is the full path of the option, e.g. decoration:blur:size
+	Path        string   `json:"path"`
+	Type        string   `json:"type"`
+	Default     string   `json:"default"`
+	Description string   `json:"description"`
+	Enum        []string `json:"enum,omitempty"`
+	Min         *float64 `json:"min,omitempty"`
+	Max         *float64 `json:"max,omitempty"`
+	// AddedIn and RemovedIn are the Hyprland versions the option was added and removed in, if known
+	AddedIn   string `json:"addedIn,omitempty"`
+	RemovedIn string `json:"removedIn,omitempty"`
+}
+
+type CatalogKeyword struct {
+	Name          string                   `json:"name"`
+	Description   string                   `json:"description"`
+	Documentation string                   `json:"documentation"`
+	Flags         []string                 `json:"flags"`
+	Arguments     []CatalogKeywordArgument `json:"arguments"`
+}
+
+type CatalogKeywordArgument struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Optional    bool   `json:"optional"`
+	Rest        bool   `json:"rest"`
+}
+
+// BuildCatalog returns the catalog of the documented configuration. Sections are sorted by path, and keywords by name.
+func BuildCatalog() Catalog {
+	catalog := Catalog{
+		FormatVersion:    CatalogFormatVersion,
+		HyprlandVersions: Versions,
+		Sections:         make([]CatalogSection, 0, len(Sections)),
+		Keywords:         make([]CatalogKeyword, 0, len(Keywords)),
+	}
+
+	for _, sec := range Sections {
+		section := CatalogSection{
+			Path:    sec.PathString(),
+			Options: make([]CatalogOption, 0, len(sec.Variables)),
+		}
+		for _, v := range sec.Variables {
+			option := CatalogOption{
+				Name:        v.Name,
+				Path:        section.Path + PathSeparator + v.Name,
+				Type:        v.Type,
+				Default:     v.Default,
+				Description: v.Description,
+				Enum:        v.Enum,
+				Min:         v.Min,
+				Max:         v.Max,
+				AddedIn:     v.AddedIn,
+				RemovedIn:   v.RemovedIn,
+			}
+			section.Options = append(section.Options, option)
+		}
+		catalog.Sections = append(catalog.Sections, section)
+	}
+	sort.SliceStable(catalog.Sections, func(i, j int) bool {
+		return catalog.Sections[i].Path < catalog.Sections[j].Path
+	})
+
+	for _, kw := range Keywords {
+		keyword := CatalogKeyword{
+			Name:          kw.Name,
+			Description:   strings.TrimSpace(kw.Description),
+			Documentation: kw.DocumentationLink(),
+			Flags:         append([]string{}, kw.Flags...),
+			Arguments:     make([]CatalogKeywordArgument, 0, len(kw.Arguments)),
+		}
+		for _, arg := range kw.Arguments {
+			keyword.Arguments = append(keyword.Arguments, CatalogKeywordArgument(arg))
+		}
+		catalog.Keywords = append(catalog.Keywords, keyword)
+	}
+	sort.SliceStable(catalog.Keywords, func(i, j int) bool {
+		return catalog.Keywords[i].Name < catalog.Keywords[j].Name
+	})
+
+	return catalog
+}
+
+// SchemaID is the identifier of the JSON Schema returned by JSONSchema. It changes with CatalogFormatVersion.
+var SchemaID = "https://github.com/ewen-lbh/hyprls/schemas/v" + strconv.Itoa(CatalogFormatVersion) + "/configuration.schema.json"
+
+// JSONSchema returns a JSON Schema (draft 2020-12) of the options and custom variables of the JSON representation of a configuration.
+// parser.JSONSchema completes it with the lists of statements, which are described by types that this package cannot import.
+func JSONSchema() map[string]any {
+	properties := map[string]any{
+		"variables": map[string]any{
+			"description":          "custom variables defined with $name = value",
+			"type":                 "object",
+			"additionalProperties": map[string]any{"type": "string"},
+		},
+	}
+	for _, sec := range Sections {
+		if len(sec.Path) == 1 {
+			properties[sec.JSONName()] = sectionSchema(sec)
+		}
+	}
+
+	return map[string]any{
+		"$schema":    "https://json-schema.org/draft/2020-12/schema",
+		"$id":        SchemaID,
+		"title":      "Hyprland configuration",
+		"type":       "object",
+		"properties": properties,
+	}
+}
+
+func sectionSchema(section SectionDefinition) map[string]any {
+	properties := make(map[string]any)
+	for _, v := range section.Variables {
+		properties[v.Name] = variableSchema(v)
+	}
+	for _, sub := range section.Subsections {
+		properties[sub.JSONName()] = sectionSchema(sub)
+	}
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+func variableSchema(v VariableDefinition) map[string]any {
+	schema := map[string]any{
+		"description": v.Description,
+	}
+	switch v.Type {
+	case "int":
+		schema["type"] = "integer"
+	case "float", "floatvalue":
+		schema["type"] = "number"
+	case "bool":
+		schema["type"] = "boolean"
+	case "vec2":
+		schema["type"] = "array"
+		schema["items"] = map[string]any{"type": "number"}
+		schema["minItems"] = 2
+		schema["maxItems"] = 2
+	default:
+		schema["type"] = "string"
+	}
+
+	if v.Enum != nil {
+		schema["enum"] = v.Enum
+	}
+	if v.Min != nil {
+		schema["minimum"] = *v.Min
+	}
+	if v.Max != nil {
+		schema["maximum"] = *v.Max
+	}
+	if def, ok := defaultValue(v); ok {
+		schema["default"] = def
+	}
+	return schema
+}
+
+// defaultValue returns the default value of v in its JSON representation. ok is false when the variable has no default value.
+func defaultValue(v VariableDefinition) (value any, ok bool) {
+	raw := strings.TrimSpace(v.Default)
+	if raw == "" || strings.EqualFold(raw, "[[Empty]]") {
+		return nil, false
+	}
+
+	switch v.Type {
+	case "int":
+		if i, err := strconv.Atoi(raw); err == nil {
+			return i, true
+		}
+	case "float", "floatvalue":
+		if f, err := strconv.ParseFloat(raw, 64); err == nil {
+			return f, true
+		}
+	case "bool":
+		switch strings.ToLower(raw) {
+		case "true", "yes", "on", "1":
+			return true, true
+		case "false", "no", "off", "0":
+			return false, true
+		}
+	case "vec2":
+		var vec [2]float64
+		if err := json.Unmarshal([]byte(raw), &vec); err == nil {
+			return vec, true
+		}
+	default:
+		return raw, true
+	}
+	return nil, false
+}
@@ -0,0 +1,78 @@
+package parser_data
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBuildCatalog(t *testing.T) {
+	catalog := BuildCatalog()
+	if catalog.FormatVersion != CatalogFormatVersion {
+		t.Fatalf("unexpected format version %d", catalog.FormatVersion)
+	}
+
+	options := make(map[string]CatalogOption)
+	for _, section := range catalog.Sections {
+		for _, option := range section.Options {
+			options[option.Path] = option
+		}
+	}
+
+	layout, ok := options["general:layout"]
+	if !ok {
+		t.Fatal("general:layout not in catalog")
+	}
+	if len(layout.Enum) != 2 || layout.Enum[0] != "dwindle" || layout.Enum[1] != "master" {
+		t.Errorf("unexpected enum for general:layout: %v", layout.Enum)
+	}
+
+	if _, ok := options["input:touchpad:natural_scroll"]; !ok {
+		t.Error("input:touchpad:natural_scroll not in catalog")
+	}
+
+	opacity := options["decoration:active_opacity"]
+	if opacity.Min == nil || opacity.Max == nil || *opacity.Min != 0 || *opacity.Max != 1 {
+		t.Errorf("unexpected range for decoration:active_opacity: %v - %v", opacity.Min, opacity.Max)
+	}
+	if gaps := options["general:gaps_in"]; gaps.Min != nil || gaps.Enum != nil {
+		t.Errorf("general:gaps_in should have no range nor enum, got %v", gaps)
+	}
+
+	for _, keyword := range catalog.Keywords {
+		if len(keyword.Arguments) == 0 {
+			t.Errorf("keyword %s has no arguments", keyword.Name)
+		}
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	schema := JSONSchema()
+	if schema["$id"] != SchemaID {
+		t.Fatalf("unexpected $id %v", schema["$id"])
+	}
+
+	general := schema["properties"].(map[string]any)["general"].(map[string]any)
+	gapsIn := general["properties"].(map[string]any)["gaps_in"].(map[string]any)
+	if gapsIn["type"] != "integer" || gapsIn["default"] != 5 {
+		t.Errorf("unexpected schema for general:gaps_in: %v", gapsIn)
+	}
+
+	input := schema["properties"].(map[string]any)["input"].(map[string]any)
+	for _, device := range []string{"touchpad", "touchdevice", "tablet"} {
+		if _, ok := input["properties"].(map[string]any)[device]; !ok {
+			t.Errorf("input:%s is not in the schema", device)
+		}
+	}
+	touchpad, _ := input["properties"].(map[string]any)["touchpad"].(map[string]any)
+	touchpadOptions, _ := touchpad["properties"].(map[string]any)
+	if naturalScroll, ok := touchpadOptions["natural_scroll"].(map[string]any); !ok || naturalScroll["type"] != "boolean" {
+		t.Errorf("unexpected schema for input:touchpad:natural_scroll: %v", touchpad)
+	}
+	if _, ok := schema["properties"].(map[string]any)["custom accel profiles"]; ok {
+		t.Error("headings that are not sections should not be in the schema")
+	}
+
+	if _, err := json.Marshal(schema); err != nil {
+		t.Fatalf("schema cannot be encoded: %s", err)
+	}
+}
@@ -0,0 +1,96 @@
+package parser_data
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// VariableConstraints restrict the values of a variable, see VariableDefinition.Enum, Min and Max
+type VariableConstraints struct {
+	Enum []string
+	Min  *float64
+	Max  *float64
+}
+
+// variableConstraints are the constraints of variables that the wiki doesn't spell out in a way the scraper understands, by full path.
+// They replace the ones derived from the description of the variable.
+var variableConstraints = map[string]VariableConstraints{
+	"general:border_size":               {Min: bound(0)},
+	"general:resize_corner":             {Min: bound(0), Max: bound(4)},
+	"decoration:rounding":               {Min: bound(0)},
+	"decoration:blur:size":              {Min: bound(1)},
+	"decoration:blur:passes":            {Min: bound(1), Max: bound(4)},
+	"input:repeat_rate":                 {Min: bound(0)},
+	"input:repeat_delay":                {Min: bound(0)},
+	"input:float_switch_override_focus": {Min: bound(0), Max: bound(2)},
+	"input:off_window_axis_events":      {Min: bound(0), Max: bound(3)},
+	"misc:initial_workspace_tracking":   {Min: bound(0), Max: bound(2)},
+	"binds:workspace_center_on":         {Min: bound(0), Max: bound(1)},
+	"binds:focus_preferred_method":      {Min: bound(0), Max: bound(1)},
+	"opengl:force_introspection":        {Min: bound(0), Max: bound(2)},
+	"debug:damage_tracking":             {Min: bound(0), Max: bound(2)},
+	"dwindle:force_split":               {Min: bound(0), Max: bound(2)},
+	"master:orientation":                {Enum: []string{"left", "right", "top", "bottom", "center"}},
+}
+
+func bound(n float64) *float64 {
+	return &n
+}
+
+// applyConstraints sets the constraints of the variables of sections from variableConstraints
+func applyConstraints(sections []SectionDefinition) {
+	for i, section := range sections {
+		for j, v := range section.Variables {
+			constraints, ok := variableConstraints[section.PathString()+PathSeparator+v.Name]
+			if !ok {
+				continue
+			}
+			if constraints.Enum != nil {
+				sections[i].Variables[j].Enum = constraints.Enum
+			}
+			if constraints.Min != nil {
+				sections[i].Variables[j].Min = constraints.Min
+			}
+			if constraints.Max != nil {
+				sections[i].Variables[j].Max = constraints.Max
+			}
+		}
+		applyConstraints(section.Subsections)
+	}
+}
+
+// CheckValue returns a description of what is wrong with value, if it is not allowed by the constraints of the variable.
+// Values that are not numbers are not checked against bounds, since they could be written in another form, such as css-style gaps.
+// ok is true if the value is allowed.
+func (v VariableDefinition) CheckValue(value string) (problem string, ok bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", true
+	}
+
+	if v.Enum != nil {
+		// Some values take arguments after the value itself, as in accel_profile = custom 200 0.0 0.5
+		if first := strings.Fields(value)[0]; !slices.Contains(v.Enum, first) {
+			return "must be one of " + strings.Join(v.Enum, ", "), false
+		}
+	}
+
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return "", true
+	}
+	switch {
+	case v.Min != nil && v.Max != nil && (n < *v.Min || n > *v.Max):
+		return "must be between " + formatBound(*v.Min) + " and " + formatBound(*v.Max), false
+	case v.Min != nil && n < *v.Min:
+		return "must be at least " + formatBound(*v.Min), false
+	case v.Max != nil && n > *v.Max:
+		return "must be at most " + formatBound(*v.Max), false
+	}
+	return "", true
+}
+
+func formatBound(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
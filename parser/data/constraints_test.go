@@ -0,0 +1,60 @@
+package parser_data
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestVariableConstraintsOverrides(t *testing.T) {
+	_, passes := FindVariableByPath("decoration:blur:passes")
+	if passes == nil || passes.Min == nil || passes.Max == nil || *passes.Min != 1 || *passes.Max != 4 {
+		t.Fatalf("unexpected constraints for decoration:blur:passes: %+v", passes)
+	}
+
+	_, orientation := FindVariableByPath("master:orientation")
+	if orientation == nil || !slices.Equal(orientation.Enum, []string{"left", "right", "top", "bottom", "center"}) {
+		t.Fatalf("unexpected constraints for master:orientation: %+v", orientation)
+	}
+
+	for path := range variableConstraints {
+		if _, def := FindVariableByPath(path); def == nil {
+			t.Errorf("constraints are given for %s, which is not a variable", path)
+		}
+	}
+}
+
+func TestCheckValue(t *testing.T) {
+	layout := VariableDefinition{Name: "layout", Type: "str", Enum: []string{"dwindle", "master"}}
+	passes := VariableDefinition{Name: "passes", Type: "int", Min: bound(1), Max: bound(4)}
+	rounding := VariableDefinition{Name: "rounding", Type: "int", Min: bound(0)}
+	profile := VariableDefinition{Name: "accel_profile", Type: "str", Enum: []string{"adaptive", "flat", "custom"}}
+
+	tests := []struct {
+		variable VariableDefinition
+		value    string
+		problem  string
+	}{
+		{layout, "master", ""},
+		{layout, "hy3", "must be one of dwindle, master"},
+		{layout, "", ""},
+		{profile, "custom 200 0.0 0.5", ""},
+		{passes, " 3", ""},
+		{passes, "6", "must be between 1 and 4"},
+		{rounding, "-2", "must be at least 0"},
+		{rounding, "10", ""},
+		{passes, "lots", ""},
+	}
+	for _, test := range tests {
+		problem, ok := test.variable.CheckValue(test.value)
+		if problem != test.problem || ok != (test.problem == "") {
+			t.Errorf("%s = %q: expected %q, got %q (ok: %v)", test.variable.Name, test.value, test.problem, problem, ok)
+		}
+	}
+}
+
+func TestUnknownTypes(t *testing.T) {
+	v := VariableDefinition{Name: "monitor", Type: "str / int"}
+	if v.GoType() != "string" || v.ParserTypeString() != "String" {
+		t.Errorf("unknown types should be strings, got %s and %s", v.GoType(), v.ParserTypeString())
+	}
+}
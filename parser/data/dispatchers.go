@@ -0,0 +1,64 @@
+package parser_data
+
+import (
+	"fmt"
+	"slices"
+)
+
+// DispatcherParameterKind is a kind of value that dispatchers take as parameter
+type DispatcherParameterKind string
+
+const (
+	// ParameterWorkspace is a workspace selector, e.g. 1, +1, name:Web or special:scratchpad
+	ParameterWorkspace DispatcherParameterKind = "workspace"
+	// ParameterWindow is a window selector, e.g. class:^(kitty)$ or address:0x1234
+	ParameterWindow DispatcherParameterKind = "window"
+	// ParameterDirection is one of l, r, u or d
+	ParameterDirection DispatcherParameterKind = "direction"
+	// ParameterMonitor is a monitor selector: a direction, an ID, a name, current or a relative ID
+	ParameterMonitor DispatcherParameterKind = "monitor"
+	// ParameterToggle is one of on, off or toggle
+	ParameterToggle DispatcherParameterKind = "toggle"
+	// ParameterCommand is a shell command line, taking the rest of the line
+	ParameterCommand DispatcherParameterKind = "command"
+)
+
+// Directions are the values of ParameterDirection parameters
+var Directions = []string{"l", "r", "u", "d"}
+
+// Toggles are the values of ParameterToggle parameters
+var Toggles = []string{"on", "off", "toggle"}
+
+type DispatcherDefinition struct {
+	Name        string
+	Description string
+	// Params is how the parameters are written, as described on the wiki, e.g. "workspace OR workspace,window for a specific window". It is "none" for dispatchers that take no parameters.
+	Params string
+	// ParameterKinds are the kinds of values the parameters can be, in the order the wiki mentions them. It is empty when the parameters are none of the known kinds.
+	ParameterKinds []DispatcherParameterKind
+	// DocumentationFile is the wiki page that documents the dispatcher, and DocumentationHeadingSlug the anchor of the table that lists it
+	DocumentationFile        string
+	DocumentationHeadingSlug string
+}
+
+func (d DispatcherDefinition) DocumentationLink() string {
+	return fmt.Sprintf("https://wiki.hyprland.org/Configuring/%s/#%s", d.DocumentationFile, d.DocumentationHeadingSlug)
+}
+
+// TakesParameterKind returns true if kind is one of the kinds of parameters the dispatcher takes
+func (d DispatcherDefinition) TakesParameterKind(kind DispatcherParameterKind) bool {
+	return slices.Contains(d.ParameterKinds, kind)
+}
+
+// Dispatchers are the dispatchers that binds can call, loaded from the documentation
+var Dispatchers = []DispatcherDefinition{}
+
+// FindDispatcher returns the dispatcher named name
+func FindDispatcher(name string) (dispatcher DispatcherDefinition, found bool) {
+	for _, d := range Dispatchers {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return DispatcherDefinition{}, false
+}
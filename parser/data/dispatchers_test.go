@@ -0,0 +1,22 @@
+package parser_data
+
+import "testing"
+
+func TestFindDispatcher(t *testing.T) {
+	d, found := FindDispatcher("movefocus")
+	if !found {
+		t.Fatal("movefocus not found")
+	}
+	if !d.TakesParameterKind(ParameterDirection) {
+		t.Errorf("movefocus should take a direction, takes %v", d.ParameterKinds)
+	}
+
+	for _, name := range []string{"layoutmsg", "resizewindow", "pseudo", "togglesplit"} {
+		if _, found := FindDispatcher(name); !found {
+			t.Errorf("%s not found", name)
+		}
+	}
+	if _, found := FindDispatcher("movefocuss"); found {
+		t.Error("movefocuss should not be found")
+	}
+}
@@ -2,14 +2,49 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
 
 	"github.com/MakeNowJust/heredoc"
 	. "github.com/ewen-lbh/hyprls/parser/data"
+	"github.com/ewen-lbh/hyprls/parser/data/wiki"
 )
 
+// nonSectionFields are the fields of Configuration that are not option sections: custom variables, and keyword statements decoded into the types declared in the parser package
+const nonSectionFields = "\tCustomVariables map[string]string `json:\"variables\"`\n\n" +
+	"\tKeybinds []Bind `json:\"keybinds\"`\n" +
+	"\tSubmaps []string `json:\"submaps\"`\n" +
+	"\tMonitors []Monitor `json:\"monitors\"`\n" +
+	"\tWorkspaceRules []WorkspaceRule `json:\"workspacerules\"`\n" +
+	"\tWindowRules []WindowRule `json:\"windowrules\"`\n" +
+	"\tLayerRules []LayerRule `json:\"layerrules\"`\n" +
+	"\tBeziers []Bezier `json:\"beziers\"`\n" +
+	"\tAnimationRules []Animation `json:\"animationrules\"`\n" +
+	"\tExecs []Exec `json:\"execs\"`\n" +
+	"\tEnv []Env `json:\"env\"`\n"
+
+// Usage: generate -documentation ../documentation.go -catalog catalog.json > ../../highlevel.go
 func main() {
+	documentationPath := flag.String("documentation", "../documentation.go", "where to write the documentation scraped from the wiki pages, as a Go file of the parser_data package")
+	catalogPath := flag.String("catalog", "catalog.json", "where to write the catalog")
+	flag.Parse()
+
+	documentation, err := wiki.ParseEmbedded()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "while scraping the wiki pages: %s\n", err)
+		os.Exit(1)
+	}
+	source, err := wiki.GoSource(documentation)
+	if err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile(*documentationPath, source, 0644); err != nil {
+		panic(err)
+	}
+	// The documentation that was compiled in might be outdated
+	UseDocumentation(documentation)
+
 	rootSections := make([]SectionDefinition, 0)
 	for _, section := range Sections {
 		if len(section.Path) == 1 {
@@ -17,32 +52,31 @@ func main() {
 		}
 	}
 
-	jsoned, _ := json.Marshal(struct {
-		Sections []SectionDefinition `json:"sections"`
-		Keywords []KeywordDefinition `json:"keywords"`
-	}{
-		Sections: rootSections,
-		Keywords: Keywords,
-	})
-	os.WriteFile(os.Args[1], jsoned, 0644)
+	catalog, err := json.MarshalIndent(BuildCatalog(), "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile(*catalogPath, append(catalog, '\n'), 0644); err != nil {
+		panic(err)
+	}
 
 	fmt.Println(heredoc.Doc(`package parser
 
 		import "image/color"
 
 		type Configuration struct {
-			CustomVariables map[string]string
 	`))
+	fmt.Print(nonSectionFields, "\n")
 
 	for _, section := range rootSections {
 		fmt.Printf("\t%s %s `json:\"%s\"`\n", section.Name(), section.TypeName(), section.JSONName())
 	}
 
-	fmt.Println("}\n\n")
+	fmt.Print("}\n\n\n")
 
 	for _, section := range rootSections {
 		fmt.Println(section.Typedef())
 	}
 
-	fmt.Println("\n\n")
+	fmt.Print("\n\n\n")
 }
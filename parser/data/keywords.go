@@ -2,132 +2,329 @@ package parser_data
 
 import (
 	"fmt"
+	"path"
+	"slices"
 	"strings"
 )
 
 type KeywordDefinition struct {
-	Name                     string
-	Description              string
-	documentationHeadingSlug string
-	documentationFile        string
+	Name        string
+	Description string
+	// DocumentationFile is the wiki page that documents the keyword, relative to the Configuring pages, and DocumentationHeadingSlug the anchor of the heading in that page
+	DocumentationHeadingSlug string
+	DocumentationFile        string
 	Flags                    []string
+	// Arguments are the comma-separated arguments the keyword takes
+	Arguments []KeywordArgument
+}
+
+// KeywordArgument is an argument of a keyword. Types are the same as the ones of variables.
+type KeywordArgument struct {
+	Name        string
+	Type        string
+	Description string
+	Optional    bool
+	// Rest is true for the last argument of keywords that take the rest of the line, commas included
+	Rest bool
 }
 
 func (k KeywordDefinition) DocumentationLink() string {
-	return fmt.Sprintf("https://wiki.hyprland.org/Configuring/%s/#%s", k.documentationFile, k.documentationHeadingSlug)
+	return fmt.Sprintf("https://wiki.hyprland.org/%s/#%s", path.Join("Configuring", k.DocumentationFile), k.DocumentationHeadingSlug)
 }
 
-var Keywords = []KeywordDefinition{
+// Keywords are every keyword of the configuration, loaded from the documentation
+var Keywords = []KeywordDefinition{}
+
+// KeywordOverrides correct the keywords scraped from the wiki, see OverrideKeywords.
+// They give the arguments of keywords, which the wiki only shows through examples, the headings that document them best when it's not the first one that uses them, and the flags that the wiki doesn't list.
+// Only keywords that the embedded wiki snapshots document belong here, which TestKeywordOverrides in the wiki package checks. The other ones go in UndocumentedKeywords.
+var KeywordOverrides = []KeywordDefinition{
 	{
-		Name:                     "submap",
-		documentationHeadingSlug: "submaps",
-		documentationFile:        "Binds",
-		Flags:                    []string{},
+		Name: "submap",
+		Arguments: []KeywordArgument{
+			{Name: "name", Type: "str", Description: "name of the submap to enter, or reset to go back to the global one"},
+		},
 	},
 	{
 		Name:                     "windowrule",
-		documentationHeadingSlug: "window-rules-v1",
-		documentationFile:        "Window-Rules",
-		Flags:                    []string{},
+		DocumentationHeadingSlug: "window-rules-v1",
+		DocumentationFile:        "Window-Rules",
+		Arguments: []KeywordArgument{
+			{Name: "rule", Type: "str", Description: "rule to apply"},
+			{Name: "window", Type: "str", Description: "regular expression matched against the window's class or title", Rest: true},
+		},
 	},
 	{
-		Name:                     "windowrulev2",
-		documentationHeadingSlug: "window-rules-v2",
-		documentationFile:        "Window-Rules",
-		Flags:                    []string{},
+		Name: "windowrulev2",
+		Arguments: []KeywordArgument{
+			{Name: "rule", Type: "str", Description: "rule to apply"},
+			{Name: "matchers", Type: "str", Description: "comma-separated list of property:regex pairs the window must match", Rest: true},
+		},
 	},
 	{
 		Name:                     "layerrule",
-		documentationHeadingSlug: "layer-rules",
-		documentationFile:        "Window-Rules",
-		Flags:                    []string{},
+		DocumentationHeadingSlug: "layer-rules",
+		DocumentationFile:        "Window-Rules",
+		Arguments: []KeywordArgument{
+			{Name: "rule", Type: "str", Description: "rule to apply"},
+			{Name: "namespace", Type: "str", Description: "regular expression matched against the layer's namespace or address"},
+		},
 	},
 	{
 		Name:                     "workspace",
-		documentationHeadingSlug: "workspace-rules",
-		documentationFile:        "Workspace-Rules",
-		Flags:                    []string{},
+		DocumentationHeadingSlug: "workspace-rules",
+		DocumentationFile:        "Workspace-Rules",
+		Arguments: []KeywordArgument{
+			{Name: "workspace", Type: "str", Description: "workspace identifier, e.g. 1, name:coding or special:scratchpad"},
+			{Name: "rules", Type: "str", Description: "comma-separated list of rule:value pairs", Optional: true, Rest: true},
+		},
 	},
 	{
-		Name:                     "animation",
-		documentationHeadingSlug: "general",
-		documentationFile:        "Animations",
-		Flags:                    []string{},
+		Name: "animation",
+		Arguments: []KeywordArgument{
+			{Name: "name", Type: "str", Description: "name of the animation, e.g. windows or workspaces"},
+			{Name: "onoff", Type: "bool", Description: "whether the animation is enabled"},
+			{Name: "speed", Type: "float", Description: "duration in deciseconds", Optional: true},
+			{Name: "curve", Type: "str", Description: "name of the bezier curve to use", Optional: true},
+			{Name: "style", Type: "str", Description: "style of the animation, for the animations that support it", Optional: true},
+		},
 	},
 	{
-		Name:                     "bezier",
-		documentationHeadingSlug: "curves",
-		documentationFile:        "Animations",
-		Flags:                    []string{},
+		Name: "bezier",
+		Arguments: []KeywordArgument{
+			{Name: "name", Type: "str", Description: "name of the curve"},
+			{Name: "X0", Type: "float"},
+			{Name: "Y0", Type: "float"},
+			{Name: "X1", Type: "float"},
+			{Name: "Y1", Type: "float"},
+		},
 	},
 	{
-		Name:                     "exec",
-		documentationHeadingSlug: "executing",
-		documentationFile:        "Keywords",
-		Flags:                    []string{},
+		Name: "exec",
+		Arguments: []KeywordArgument{
+			{Name: "command", Type: "str", Description: "shell command to run on every reload", Rest: true},
+		},
 	},
 	{
-		Name:                     "exec-once",
-		documentationHeadingSlug: "executing",
-		documentationFile:        "Keywords",
-		Flags:                    []string{},
+		Name: "exec-once",
+		Arguments: []KeywordArgument{
+			{Name: "command", Type: "str", Description: "shell command to run on launch only", Rest: true},
+		},
 	},
 	{
-		Name:                     "source",
-		documentationHeadingSlug: "sourcing-multi-file",
-		documentationFile:        "Keywords",
-		Flags:                    []string{},
+		Name: "source",
+		Arguments: []KeywordArgument{
+			{Name: "path", Type: "str", Description: "path or glob pattern of the files to include"},
+		},
 	},
 	{
-		Name:                     "env",
-		documentationHeadingSlug: "setting-the-environment",
-		documentationFile:        "Keywords",
-		Flags:                    []string{"d"},
+		Name: "env",
+		// The wiki documents envd as another keyword
+		Flags: []string{"d"},
+		Arguments: []KeywordArgument{
+			{Name: "name", Type: "str", Description: "name of the environment variable"},
+			{Name: "value", Type: "str", Rest: true},
+		},
 	},
 	{
-		Name:                     "monitor",
-		documentationHeadingSlug: "general",
-		documentationFile:        "Monitors",
-		Flags:                    []string{},
+		Name: "monitor",
+		Arguments: []KeywordArgument{
+			{Name: "name", Type: "str", Description: "name or description of the monitor, or empty for any monitor"},
+			{Name: "resolution", Type: "str", Description: "e.g. 1920x1080@144, preferred, highres, highrr or disable"},
+			{Name: "position", Type: "str", Description: "e.g. 0x0 or auto", Optional: true},
+			{Name: "scale", Type: "str", Description: "e.g. 1.5 or auto", Optional: true},
+			{Name: "options", Type: "str", Description: "extra options, e.g. transform, 1 or mirror, DP-1", Optional: true, Rest: true},
+		},
 	},
 	{
-		Name:                     "bind",
-		documentationHeadingSlug: "basic",
-		documentationFile:        "Binds",
-		Flags:                    []string{"r", "l", "e", "n", "m", "t", "i"},
+		Name: "bind",
+		// Flags added after the embedded wiki snapshots
+		Flags: []string{"o", "s", "d", "p", "c", "g"},
+		Arguments: []KeywordArgument{
+			{Name: "mods", Type: "MOD", Description: "modifier keys, can be empty"},
+			{Name: "key", Type: "str", Description: "key name or keycode, e.g. Q or code:24"},
+			{Name: "description", Type: "str", Description: "only with the d flag: description of the bind", Optional: true},
+			{Name: "dispatcher", Type: "str"},
+			{Name: "params", Type: "str", Description: "parameters of the dispatcher", Optional: true, Rest: true},
+		},
 	},
 	{
-		Name:                     "unbind",
-		documentationHeadingSlug: "unbind",
-		documentationFile:        "Binds",
-		Flags:                    []string{},
+		Name: "unbind",
+		Arguments: []KeywordArgument{
+			{Name: "mods", Type: "MOD"},
+			{Name: "key", Type: "str"},
+		},
 	},
 }
 
+// UndocumentedKeywords are the keywords that the embedded wiki snapshots don't document yet, because they were added after them or are documented on other pages.
+// Move a keyword to KeywordOverrides once a snapshot documents it.
+var UndocumentedKeywords = []KeywordDefinition{
+	{
+		Name:                     "execr",
+		DocumentationHeadingSlug: "executing",
+		DocumentationFile:        "Keywords",
+		Description:              "`execr=command` will execute a raw shell command on each reload, without support for rules",
+		Arguments: []KeywordArgument{
+			{Name: "command", Type: "str", Description: "shell command to run on every reload", Rest: true},
+		},
+	},
+	{
+		Name:                     "execr-once",
+		DocumentationHeadingSlug: "executing",
+		DocumentationFile:        "Keywords",
+		Description:              "`execr-once=command` will execute a raw shell command only on launch, without support for rules",
+		Arguments: []KeywordArgument{
+			{Name: "command", Type: "str", Description: "shell command to run on launch only", Rest: true},
+		},
+	},
+	{
+		Name:                     "exec-shutdown",
+		DocumentationHeadingSlug: "executing",
+		DocumentationFile:        "Keywords",
+		Description:              "`exec-shutdown=command` will execute only on shutdown",
+		Arguments: []KeywordArgument{
+			{Name: "command", Type: "str", Description: "shell command to run when Hyprland exits", Rest: true},
+		},
+	},
+	{
+		Name:                     "plugin",
+		DocumentationHeadingSlug: "manual",
+		DocumentationFile:        "../Plugins/Using-Plugins",
+		Description:              "Loads a plugin from a shared object file",
+		Arguments: []KeywordArgument{
+			{Name: "path", Type: "str", Description: "absolute path of the .so file of the plugin"},
+		},
+	},
+	{
+		Name:                     "permission",
+		DocumentationHeadingSlug: "permissions",
+		DocumentationFile:        "Permissions",
+		Description:              "Allows or denies an application a permission, such as capturing the screen or loading plugins",
+		Arguments: []KeywordArgument{
+			{Name: "binary", Type: "str", Description: "regular expression matched against the path of the binary, or the name of the device for keyboards"},
+			{Name: "type", Type: "str", Description: "screencopy, plugin or keyboard"},
+			{Name: "mode", Type: "str", Description: "allow, ask or deny"},
+		},
+	},
+	{
+		Name:                     "gesture",
+		DocumentationHeadingSlug: "gestures",
+		DocumentationFile:        "Gestures",
+		Description:              "Binds an action to a touchpad swipe gesture",
+		Arguments: []KeywordArgument{
+			{Name: "fingers", Type: "int", Description: "number of fingers of the gesture"},
+			{Name: "direction", Type: "str", Description: "e.g. horizontal, vertical, swipe, pinch, left or up"},
+			{Name: "options", Type: "str", Description: "mod: and scale: options", Optional: true},
+			{Name: "action", Type: "str", Description: "e.g. workspace, move, resize, close, float, dispatcher or special", Rest: true},
+		},
+	},
+}
+
+// FindKeyword returns the keyword of a statement, which can be written with flags after its name, as in bindel
 func FindKeyword(key string) (keyword KeywordDefinition, found bool) {
 	for _, k := range Keywords {
-		if key == k.Name {
+		if key == k.Name || hasFlags(key, k) {
 			return k, true
 		}
+	}
+	return KeywordDefinition{}, false
+}
+
+// hasFlags returns true if key is the name of the keyword followed by some of its flags
+func hasFlags(key string, keyword KeywordDefinition) bool {
+	flags, ok := strings.CutPrefix(key, keyword.Name)
+	if !ok || flags == "" {
+		return false
+	}
+	for _, flag := range strings.Split(flags, "") {
+		if !slices.Contains(keyword.Flags, flag) {
+			return false
+		}
+	}
+	return true
+}
 
-		if strings.HasPrefix(key, k.Name) {
-			if len(k.Flags) > 0 {
-				usedFlags := strings.Split(strings.TrimPrefix(key, k.Name), "")
-				for _, used := range usedFlags {
-					flagIsValid := false
-					for _, available := range k.Flags {
-						if used == available {
-							flagIsValid = true
-							break
-						}
-					}
-					if !flagIsValid {
-						return KeywordDefinition{}, false
-					}
-				}
-				return k, true
+// OverrideKeywords completes the keywords scraped from the wiki with KeywordOverrides and UndocumentedKeywords.
+// The arguments, description and heading that an override sets replace the scraped ones, and its flags are added to the scraped ones. Overrides of keywords that were not scraped are ignored.
+// Undocumented keywords that were not scraped are added after the scraped ones.
+// Scraped keywords that are another keyword with flags, such as bindm or envd, are removed.
+func OverrideKeywords(scraped []KeywordDefinition) []KeywordDefinition {
+	keywords := append([]KeywordDefinition{}, scraped...)
+	for _, override := range KeywordOverrides {
+		i := slices.IndexFunc(keywords, func(k KeywordDefinition) bool { return k.Name == override.Name })
+		if i == -1 {
+			continue
+		}
+		if override.Description != "" {
+			keywords[i].Description = override.Description
+		}
+		if override.DocumentationFile != "" {
+			keywords[i].DocumentationFile = override.DocumentationFile
+			keywords[i].DocumentationHeadingSlug = override.DocumentationHeadingSlug
+		}
+		for _, flag := range override.Flags {
+			if !slices.Contains(keywords[i].Flags, flag) {
+				keywords[i].Flags = append(slices.Clone(keywords[i].Flags), flag)
 			}
 		}
+		if override.Arguments != nil {
+			keywords[i].Arguments = override.Arguments
+		}
+	}
+	for _, undocumented := range UndocumentedKeywords {
+		if !slices.ContainsFunc(keywords, func(k KeywordDefinition) bool { return k.Name == undocumented.Name }) {
+			keywords = append(keywords, undocumented)
+		}
+	}
+	return slices.DeleteFunc(keywords, func(k KeywordDefinition) bool {
+		return slices.ContainsFunc(keywords, func(other KeywordDefinition) bool { return hasFlags(k.Name, other) })
+	})
+}
+
+// HyprlangDirectives are the directives that hyprlang reads in comments, as in # hyprlang noerror true, by name.
+// The version directive is only read by hyprls, see parser.TargetVersion.
+var HyprlangDirectives = []KeywordDefinition{
+	{
+		Name:                     "noerror",
+		Description:              "Ignores the errors of the lines that follow, such as options and keywords of plugins that are not loaded yet, until `# hyprlang noerror false`",
+		DocumentationFile:        "Configuring-Hyprland",
+		DocumentationHeadingSlug: "escaping-errors",
+		Arguments: []KeywordArgument{
+			{Name: "enabled", Type: "bool"},
+		},
+	},
+	{
+		Name:        "if",
+		Description: "Only evaluates the lines that follow, until `# hyprlang endif`, if the variable is set. With a `!` before its name, only if it is not set",
+		Arguments: []KeywordArgument{
+			{Name: "variable", Type: "str", Description: "name of the variable, without the $"},
+		},
+	},
+	{
+		Name:        "endif",
+		Description: "Ends the block of a `# hyprlang if` directive",
+		Arguments:   []KeywordArgument{},
+	},
+	{
+		Name:        "version",
+		Description: "Declares the Hyprland version the file targets, so that hyprls tells which options don't exist in that version. Hyprland ignores it",
+		Arguments: []KeywordArgument{
+			{Name: "version", Type: "str", Description: "e.g. v0.41.0"},
+		},
+	},
+}
+
+// FindHyprlangDirective returns the directive of a comment such as # hyprlang noerror true. found is false if the comment is not a hyprlang directive, or if it is an unknown one.
+func FindHyprlangDirective(comment string) (directive KeywordDefinition, found bool) {
+	fields := strings.Fields(strings.TrimSpace(comment))
+	if len(fields) < 3 || fields[0] != "#" || fields[1] != "hyprlang" {
+		return KeywordDefinition{}, false
+	}
+	for _, d := range HyprlangDirectives {
+		if d.Name == fields[2] {
+			return d, true
+		}
 	}
 	return KeywordDefinition{}, false
 }
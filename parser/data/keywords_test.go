@@ -1,6 +1,9 @@
 package parser_data
 
-import "testing"
+import (
+	"slices"
+	"testing"
+)
 
 func TestFindKeyword(t *testing.T) {
 	k, found := FindKeyword("submap")
@@ -20,3 +23,68 @@ func TestFindKeyword(t *testing.T) {
 	}
 
 }
+
+func TestFindKeywordsMissingFromTheWikiSnapshots(t *testing.T) {
+	for key, name := range map[string]string{
+		"plugin":        "plugin",
+		"permission":    "permission",
+		"gesture":       "gesture",
+		"exec-shutdown": "exec-shutdown",
+		"execr":         "execr",
+		"envd":          "env",
+		"bindo":         "bind",
+		"binddl":        "bind",
+	} {
+		k, found := FindKeyword(key)
+		if !found || k.Name != name {
+			t.Errorf("%s: expected keyword %s, got %q (found: %v)", key, name, k.Name, found)
+		}
+	}
+	if _, found := FindKeyword("bindx"); found {
+		t.Error("x is not a flag of bind")
+	}
+}
+
+func TestOverrideKeywords(t *testing.T) {
+	keywords := OverrideKeywords([]KeywordDefinition{
+		{Name: "bind", DocumentationFile: "Binds", DocumentationHeadingSlug: "basic", Flags: []string{"l", "r", "m"}, Arguments: []KeywordArgument{{Name: "mods", Type: "str"}}},
+		{Name: "bindm", DocumentationFile: "Binds", DocumentationHeadingSlug: "mouse-binds"},
+		{Name: "envd", DocumentationFile: "Keywords", DocumentationHeadingSlug: "setting-the-environment"},
+		{Name: "env", DocumentationFile: "Keywords", DocumentationHeadingSlug: "setting-the-environment"},
+		{Name: "hyprexpo-gesture", DocumentationFile: "Keywords", DocumentationHeadingSlug: "plugins", Arguments: []KeywordArgument{{Name: "value", Type: "str", Rest: true}}},
+	})
+
+	names := make([]string, 0, len(keywords))
+	for _, k := range keywords {
+		names = append(names, k.Name)
+	}
+	for _, name := range []string{"bind", "env", "hyprexpo-gesture", "plugin", "execr"} {
+		if !slices.Contains(names, name) {
+			t.Errorf("expected %s in %v", name, names)
+		}
+	}
+	if slices.Contains(names, "exec") {
+		t.Errorf("exec was not scraped, so its override should be ignored, got %v", names)
+	}
+	for _, name := range []string{"bindm", "envd"} {
+		if slices.Contains(names, name) {
+			t.Errorf("%s should be found as a keyword with flags, not be a keyword", name)
+		}
+	}
+
+	bind := keywords[slices.IndexFunc(keywords, func(k KeywordDefinition) bool { return k.Name == "bind" })]
+	if !slices.Contains(bind.Flags, "o") || !slices.Contains(bind.Flags, "l") || len(bind.Arguments) != 5 {
+		t.Errorf("the flags of bind should be completed and its arguments overridden, got %+v", bind)
+	}
+}
+
+func TestFindHyprlangDirective(t *testing.T) {
+	if d, found := FindHyprlangDirective("# hyprlang noerror true"); !found || d.Name != "noerror" {
+		t.Errorf("expected the noerror directive, got %+v", d)
+	}
+	for _, comment := range []string{"# hyprlang", "# just a comment", "# hyprlang unknown"} {
+		if _, found := FindHyprlangDirective(comment); found {
+			t.Errorf("%q should not be a directive", comment)
+		}
+	}
+}
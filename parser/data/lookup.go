@@ -0,0 +1,160 @@
+package parser_data
+
+import (
+	"sort"
+	"strings"
+)
+
+// PathSeparator separates the sections and the variable of a path, as in decoration:blur:size
+const PathSeparator = ":"
+
+// PathString returns the path of the section, as in decoration:blur
+func (s SectionDefinition) PathString() string {
+	return strings.ToLower(strings.Join(s.Path, PathSeparator))
+}
+
+// FindSectionByPath returns the section at path, e.g. decoration:blur. Names are compared case-insensitively.
+func FindSectionByPath(path string) *SectionDefinition {
+	for _, sec := range Sections {
+		if strings.EqualFold(sec.PathString(), path) {
+			return &sec
+		}
+	}
+	return nil
+}
+
+// FindVariableByPath returns the variable at path, e.g. decoration:blur:size, along with the section that contains it.
+func FindVariableByPath(path string) (*SectionDefinition, *VariableDefinition) {
+	separator := strings.LastIndex(path, PathSeparator)
+	if separator == -1 {
+		return nil, nil
+	}
+	section := FindSectionByPath(path[:separator])
+	if section == nil {
+		return nil, nil
+	}
+	variable := section.VariableDefinition(path[separator+1:])
+	if variable == nil {
+		return nil, nil
+	}
+	return section, variable
+}
+
+// SearchResult is a variable, a keyword or a dispatcher that matches a search query. Exactly one of Variable, Keyword and Dispatcher is set.
+type SearchResult struct {
+	// Path is the path of the variable, e.g. decoration:blur:size, or the name of the keyword or dispatcher
+	Path       string
+	Variable   *VariableDefinition
+	Keyword    *KeywordDefinition
+	Dispatcher *DispatcherDefinition
+	// Score is higher for better matches
+	Score int
+}
+
+// Search returns the variables, keywords and dispatchers that match query, best matches first.
+// Names match when they contain the query, when the query's characters appear in them in order, or when the query is the name with a few typos.
+// Descriptions match when they contain every word of the query.
+func Search(query string) []SearchResult {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return []SearchResult{}
+	}
+
+	results := make([]SearchResult, 0)
+	for _, sec := range Sections {
+		for _, v := range sec.Variables {
+			path := sec.PathString() + PathSeparator + v.Name
+			if score := searchScore(query, path, v.Name, v.Description); score > 0 {
+				results = append(results, SearchResult{Path: path, Variable: &v, Score: score})
+			}
+		}
+	}
+	for _, kw := range Keywords {
+		if score := searchScore(query, kw.Name, kw.Name, kw.Description); score > 0 {
+			results = append(results, SearchResult{Path: kw.Name, Keyword: &kw, Score: score})
+		}
+	}
+	for _, d := range Dispatchers {
+		if score := searchScore(query, d.Name, d.Name, d.Description); score > 0 {
+			results = append(results, SearchResult{Path: d.Name, Dispatcher: &d, Score: score})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Path < results[j].Path
+	})
+	return results
+}
+
+func searchScore(query, path, name, description string) int {
+	path, name, description = strings.ToLower(path), strings.ToLower(name), strings.ToLower(description)
+	switch {
+	case name == query || path == query:
+		return 100
+	case strings.HasPrefix(name, query):
+		return 80
+	case strings.Contains(name, query):
+		return 60
+	case strings.Contains(path, query):
+		return 50
+	}
+	if distance := editDistance(query, path); distance <= maxTypos {
+		return 45 - distance
+	}
+	if span := subsequenceSpan(query, path); span != -1 {
+		// Prefer matches where the query's characters are close together
+		return 20 + max(0, 20-(span-len(query)))
+	}
+
+	for _, word := range strings.Fields(query) {
+		if !strings.Contains(description, word) {
+			return 0
+		}
+	}
+	return 10
+}
+
+// maxTypos is the maximum edit distance between a search query and the path it matches
+const maxTypos = 2
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			substitution := previous[j-1]
+			if a[i-1] != b[j-1] {
+				substitution++
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, substitution)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// subsequenceSpan returns the length of the part of haystack that contains all the characters of needle, in the same order, or -1 if they don't all appear
+func subsequenceSpan(needle, haystack string) int {
+	start, matched := -1, 0
+	for i := 0; i < len(haystack) && matched < len(needle); i++ {
+		if haystack[i] != needle[matched] {
+			continue
+		}
+		if matched == 0 {
+			start = i
+		}
+		matched++
+		if matched == len(needle) {
+			return i - start + 1
+		}
+	}
+	return -1
+}
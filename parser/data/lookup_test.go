@@ -0,0 +1,46 @@
+package parser_data
+
+import "testing"
+
+func TestFindVariableByPath(t *testing.T) {
+	section, variable := FindVariableByPath("decoration:blur:size")
+	if variable == nil {
+		t.Fatal("decoration:blur:size not found")
+	}
+	if section.Name() != "Blur" || variable.Name != "size" {
+		t.Fatalf("unexpected result: %v, %v", section.Path, variable.Name)
+	}
+
+	if _, variable := FindVariableByPath("General:GAPS_IN"); variable != nil {
+		t.Fatal("variable names should be case-sensitive")
+	}
+	if _, variable := FindVariableByPath("nope:size"); variable != nil {
+		t.Fatal("found variable in unknown section")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	results := Search("shadow")
+	if len(results) == 0 {
+		t.Fatal("no results for shadow")
+	}
+	for i := 1; i < len(results); i++ {
+		if results[i].Score > results[i-1].Score {
+			t.Fatalf("results are not sorted by score: %v", results)
+		}
+	}
+
+	results = Search("blrsz")
+	if len(results) == 0 || results[0].Path != "decoration:blur:size" {
+		t.Fatalf("expected fuzzy match on decoration:blur:size, got %v", results)
+	}
+
+	results = Search("decoration:blur:sise")
+	if len(results) == 0 || results[0].Path != "decoration:blur:size" {
+		t.Fatalf("expected typo-tolerant match on decoration:blur:size, got %v", results)
+	}
+
+	if results := Search("bind"); results[0].Keyword == nil || results[0].Keyword.Name != "bind" {
+		t.Fatalf("expected the bind keyword first, got %v", results[0])
+	}
+}
@@ -0,0 +1,153 @@
+package parser_data
+
+import (
+	"strings"
+)
+
+// OptionMigration tells how to update an option that was renamed or moved in a Hyprland release
+type OptionMigration struct {
+	// From is the full path of the old option, e.g. decoration:drop_shadow
+	From string
+	// To is the full path of the option that replaces it, e.g. decoration:shadow:enabled
+	To string
+	// In is the Hyprland version that replaced the old option
+	In string
+	// Transform converts a value of the old option to a value of the new one. Values are kept as-is when it is nil.
+	Transform func(value string) string
+}
+
+// KeywordMigration tells how to update a statement whose keyword is a legacy form of another one
+type KeywordMigration struct {
+	// From is the legacy keyword, e.g. windowrule
+	From string
+	// To is the keyword that replaces it, e.g. windowrulev2
+	To string
+	// In is the Hyprland version that deprecated the legacy keyword, or "" if it is deprecated in every known version
+	In string
+	// Transform converts the value of a statement of the legacy keyword to a value of the new one. Values are kept as-is when it is nil.
+	Transform func(value string) string
+}
+
+// OptionMigrations are the options that were renamed or moved, oldest first.
+// Their old path is removed in the version of the migration, which sets RemovedIn on their VariableDefinition.
+var OptionMigrations = []OptionMigration{
+	// Cursor options got their own section
+	{From: "general:no_cursor_warps", To: "cursor:no_warps", In: "v0.41.0"},
+	{From: "general:cursor_inactive_timeout", To: "cursor:inactive_timeout", In: "v0.41.0"},
+	{From: "general:default_cursor_monitor", To: "cursor:default_monitor", In: "v0.41.0"},
+	{From: "misc:hide_cursor_on_touch", To: "cursor:hide_on_touch", In: "v0.41.0"},
+	{From: "misc:hide_cursor_on_key_press", To: "cursor:hide_on_key_press", In: "v0.41.0"},
+	{From: "misc:cursor_zoom_factor", To: "cursor:zoom_factor", In: "v0.41.0"},
+	{From: "misc:cursor_zoom_rigid", To: "cursor:zoom_rigid", In: "v0.41.0"},
+	{From: "misc:enable_hyprcursor", To: "cursor:enable_hyprcursor", In: "v0.41.0"},
+	{From: "master:new_is_master", To: "master:new_status", In: "v0.41.0", Transform: func(value string) string {
+		if isTrue(value) {
+			return "master"
+		}
+		return "slave"
+	}},
+	{From: "misc:no_direct_scanout", To: "render:direct_scanout", In: "v0.42.0", Transform: func(value string) string {
+		if isTrue(value) {
+			return "false"
+		}
+		return "true"
+	}},
+	// Shadow options got their own section
+	{From: "decoration:drop_shadow", To: "decoration:shadow:enabled", In: "v0.45.0"},
+	{From: "decoration:shadow_range", To: "decoration:shadow:range", In: "v0.45.0"},
+	{From: "decoration:shadow_render_power", To: "decoration:shadow:render_power", In: "v0.45.0"},
+	{From: "decoration:shadow_ignore_window", To: "decoration:shadow:ignore_window", In: "v0.45.0"},
+	{From: "decoration:col.shadow", To: "decoration:shadow:color", In: "v0.45.0"},
+	{From: "decoration:col.shadow_inactive", To: "decoration:shadow:color_inactive", In: "v0.45.0"},
+	{From: "decoration:shadow_offset", To: "decoration:shadow:offset", In: "v0.45.0"},
+	{From: "decoration:shadow_scale", To: "decoration:shadow:scale", In: "v0.45.0"},
+}
+
+// KeywordMigrations are the legacy keywords and what replaces them
+var KeywordMigrations = []KeywordMigration{
+	// windowrule only takes the syntax of windowrulev2 since v0.48.0
+	{From: "windowrule", To: "windowrulev2", In: "v0.48.0", Transform: windowRuleV1ToV2},
+}
+
+// FindOptionMigration returns the migration of the option at path, e.g. decoration:drop_shadow. Paths are compared case-insensitively.
+func FindOptionMigration(path string) (OptionMigration, bool) {
+	for _, migration := range OptionMigrations {
+		if strings.EqualFold(migration.From, path) {
+			return migration, true
+		}
+	}
+	return OptionMigration{}, false
+}
+
+// FindKeywordMigration returns the migration of a legacy keyword
+func FindKeywordMigration(keyword string) (KeywordMigration, bool) {
+	for _, migration := range KeywordMigrations {
+		if migration.From == keyword {
+			return migration, true
+		}
+	}
+	return KeywordMigration{}, false
+}
+
+// AppliesTo returns true if the option should be migrated in configurations that target version. Every migration applies to the empty version, which stands for the latest one.
+func (m OptionMigration) AppliesTo(version string) bool {
+	return version == "" || CompareVersions(version, m.In) >= 0
+}
+
+// AppliesTo returns true if the keyword should be migrated in configurations that target version. Every migration applies to the empty version, which stands for the latest one.
+func (m KeywordMigration) AppliesTo(version string) bool {
+	return version == "" || m.In == "" || CompareVersions(version, m.In) >= 0
+}
+
+// CanMigrate returns false if value has to be converted to a value of the new option but uses variables, whose values are only known once the whole configuration is evaluated
+func (m OptionMigration) CanMigrate(value string) bool {
+	return m.Transform == nil || !strings.Contains(value, "$")
+}
+
+// Migrate returns the value of the new option, given a value of the old one
+func (m OptionMigration) Migrate(value string) string {
+	if m.Transform == nil {
+		return value
+	}
+	return m.Transform(value)
+}
+
+// Migrate returns the value of a statement of the new keyword, given the value of a statement of the legacy one
+func (m KeywordMigration) Migrate(value string) string {
+	if m.Transform == nil {
+		return value
+	}
+	return m.Transform(value)
+}
+
+// windowRuleV2Fields are the fields windowrulev2 statements match windows on
+var windowRuleV2Fields = []string{
+	"class", "title", "initialClass", "initialTitle", "tag", "xwayland", "floating", "fullscreen", "pinned", "focus",
+	"group", "fullscreenstate", "workspace", "onworkspace", "content", "xdgTag", "pid", "address",
+}
+
+// windowRuleV1ToV2 converts the value of a windowrule statement, "RULE, WINDOW", to the value of a windowrulev2 statement.
+// In windowrule, WINDOW is a regular expression on the class, unless it starts with a field of windowrulev2 such as title:.
+func windowRuleV1ToV2(value string) string {
+	rule, window, ok := strings.Cut(value, ",")
+	if !ok {
+		return value
+	}
+	window = strings.TrimSpace(window)
+	if field, _, ok := strings.Cut(window, ":"); ok {
+		for _, v2Field := range windowRuleV2Fields {
+			if strings.EqualFold(strings.TrimSpace(field), v2Field) {
+				return strings.TrimSpace(rule) + ", " + window
+			}
+		}
+	}
+	return strings.TrimSpace(rule) + ", class:" + window
+}
+
+func isTrue(value string) bool {
+	switch strings.TrimSpace(value) {
+	case "true", "yes", "on", "1":
+		return true
+	}
+	return false
+}
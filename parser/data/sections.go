@@ -2,6 +2,7 @@ package parser_data
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 )
 
@@ -20,6 +21,15 @@ type SectionDefinition struct {
 	Variables   []VariableDefinition
 }
 
+func (s SectionDefinition) VariableDefinition(name string) *VariableDefinition {
+	for _, v := range s.Variables {
+		if v.Name == name {
+			return &v
+		}
+	}
+	return nil
+}
+
 func (s SectionDefinition) Name() string {
 	if len(s.Path) == 0 {
 		return ""
@@ -53,3 +63,11 @@ func (s SectionDefinition) Typedef() string {
 	}
 	return out
 }
+
+func toPascalCase(s string) string {
+	out := ""
+	for _, word := range regexp.MustCompile(`[-_\.]`).Split(s, -1) {
+		out += strings.ToUpper(word[:1]) + word[1:]
+	}
+	return out
+}
@@ -13,6 +13,15 @@ type VariableDefinition struct {
 	Description string
 	Type        string
 	Default     string
+	// AddedIn and RemovedIn are the Hyprland versions the variable was added and removed in.
+	// AddedIn is empty if the variable exists in the oldest known version, and RemovedIn if it still exists in the latest one.
+	AddedIn   string
+	RemovedIn string
+	// Enum are the values the variable is allowed to take, or nil if it takes any value
+	Enum []string
+	// Min and Max bound the values of a numeric variable. They are nil when it is unbounded.
+	Min *float64
+	Max *float64
 }
 
 func (v VariableDefinition) PrettyDefault() string {
@@ -41,20 +50,18 @@ func (v VariableDefinition) GoType() string {
 	case "gradient":
 		return "GradientValue"
 	default:
-		panic("unknown type: " + v.Type)
+		// The wiki sometimes gives several types, such as "str / int". Such values are kept as they are written.
+		return "string"
 	}
-
 }
 
-
-
 func (v VariableDefinition) ParserTypeString() string {
 	switch v.Type {
 	case "int":
 		return "Integer"
 	case "bool":
 		return "Bool"
-	case "float":
+	case "float", "floatvalue":
 		return "Float"
 	case "color":
 		return "Color"
@@ -67,7 +74,7 @@ func (v VariableDefinition) ParserTypeString() string {
 	case "gradient":
 		return "Gradient"
 	default:
-		panic("unknown type: " + v.Type)
+		return "String"
 	}
 }
 
@@ -0,0 +1,122 @@
+package parser_data
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Versions are the Hyprland versions the documentation knows about, oldest first:
+// the versions of the wiki snapshots it was scraped from, and the ones variables were added or removed in.
+var Versions = []string{}
+
+// VariableHistory records the Hyprland versions a variable was added and removed in
+type VariableHistory struct {
+	AddedIn   string
+	RemovedIn string
+}
+
+// variablesHistory is the history of variables that cannot be deduced from the wiki snapshots, by full path.
+// Variables that are still documented in the snapshots but were removed in a later version without a replacement go here.
+// Variables that were renamed or moved go in OptionMigrations instead.
+// With a single embedded snapshot, this and OptionMigrations are the only source of RemovedIn, and variables added after that snapshot are not documented at all.
+var variablesHistory = map[string]VariableHistory{
+	// Replaced by workspace rules
+	"dwindle:no_gaps_when_only": {RemovedIn: "v0.45.0"},
+	"master:no_gaps_when_only":  {RemovedIn: "v0.45.0"},
+}
+
+// ParseVersion parses a Hyprland version such as v0.41.0, 0.41 or 0.41.2, and returns it in its canonical form, e.g. v0.41.0
+func ParseVersion(raw string) (string, error) {
+	parts, err := versionParts(raw)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("v%d.%d.%d", parts[0], parts[1], parts[2]), nil
+}
+
+func versionParts(raw string) ([3]int, error) {
+	var parts [3]int
+	fields := strings.Split(strings.TrimPrefix(strings.TrimSpace(raw), "v"), ".")
+	if len(fields) < 2 || len(fields) > 3 {
+		return parts, fmt.Errorf("invalid version %q, expected a version such as v0.41.0", raw)
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return parts, fmt.Errorf("invalid version %q, expected a version such as v0.41.0", raw)
+		}
+		parts[i] = n
+	}
+	return parts, nil
+}
+
+// CompareVersions returns -1 if a is older than b, 1 if it is newer, and 0 if they are the same version.
+// Invalid versions are older than every valid one.
+func CompareVersions(a, b string) int {
+	partsA, errA := versionParts(a)
+	partsB, errB := versionParts(b)
+	switch {
+	case errA != nil && errB != nil:
+		return 0
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return 1
+	}
+	for i := range partsA {
+		if partsA[i] != partsB[i] {
+			if partsA[i] < partsB[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// SortVersions sorts versions from oldest to newest, in place
+func SortVersions(versions []string) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return CompareVersions(versions[i], versions[j]) < 0
+	})
+}
+
+// AvailableIn returns true if the variable exists in the given Hyprland version. Every variable is available in the empty version, which stands for any version.
+func (v VariableDefinition) AvailableIn(version string) bool {
+	if version == "" {
+		return true
+	}
+	if v.AddedIn != "" && CompareVersions(version, v.AddedIn) < 0 {
+		return false
+	}
+	return v.RemovedIn == "" || CompareVersions(version, v.RemovedIn) < 0
+}
+
+// applyHistory fills in the history of the variables of sections from variablesHistory and OptionMigrations, and returns the versions it mentions
+func applyHistory(sections []SectionDefinition) []string {
+	versions := make([]string, 0)
+	for i, section := range sections {
+		for j, v := range section.Variables {
+			path := section.PathString() + PathSeparator + v.Name
+			history, ok := variablesHistory[path]
+			if migration, migrated := FindOptionMigration(path); migrated {
+				history, ok = VariableHistory{RemovedIn: migration.In}, true
+			}
+			if !ok {
+				continue
+			}
+			if history.AddedIn != "" {
+				sections[i].Variables[j].AddedIn = history.AddedIn
+				versions = append(versions, history.AddedIn)
+			}
+			if history.RemovedIn != "" {
+				sections[i].Variables[j].RemovedIn = history.RemovedIn
+				versions = append(versions, history.RemovedIn)
+			}
+		}
+		applyHistory(section.Subsections)
+	}
+	return versions
+}
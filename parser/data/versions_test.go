@@ -0,0 +1,47 @@
+package parser_data
+
+import "testing"
+
+func TestParseVersion(t *testing.T) {
+	for raw, expected := range map[string]string{
+		"v0.41.0": "v0.41.0",
+		"0.41":    "v0.41.0",
+		" 0.41.2": "v0.41.2",
+	} {
+		if version, err := ParseVersion(raw); err != nil || version != expected {
+			t.Errorf("ParseVersion(%q) = %q, %v, expected %q", raw, version, err, expected)
+		}
+	}
+	for _, raw := range []string{"", "41", "v0.41.x", "0.41.0.1"} {
+		if _, err := ParseVersion(raw); err == nil {
+			t.Errorf("expected ParseVersion(%q) to fail", raw)
+		}
+	}
+}
+
+func TestSortVersions(t *testing.T) {
+	versions := []string{"v0.41.0", "v0.9.0", "v0.40.1", "v0.40.0"}
+	SortVersions(versions)
+	if versions[0] != "v0.9.0" || versions[1] != "v0.40.0" || versions[2] != "v0.40.1" || versions[3] != "v0.41.0" {
+		t.Errorf("unexpected order %v", versions)
+	}
+}
+
+func TestAvailableIn(t *testing.T) {
+	v := VariableDefinition{Name: "example", AddedIn: "v0.40.0", RemovedIn: "v0.45.0"}
+	for version, expected := range map[string]bool{
+		"":        true,
+		"v0.39.1": false,
+		"v0.40.0": true,
+		"v0.44.1": true,
+		"v0.45.0": false,
+	} {
+		if v.AvailableIn(version) != expected {
+			t.Errorf("AvailableIn(%q) = %t, expected %t", version, !expected, expected)
+		}
+	}
+
+	if _, v := FindVariableByPath("general:no_cursor_warps"); v == nil || v.RemovedIn != "v0.41.0" {
+		t.Errorf("expected the history of general:no_cursor_warps to be applied, got %+v", v)
+	}
+}
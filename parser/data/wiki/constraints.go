@@ -0,0 +1,70 @@
+package wiki
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+)
+
+// enumPattern matches lists of allowed values at the end of descriptions, e.g. [dwindle/master]
+var enumPattern = regexp.MustCompile(`\[([^\]/\s]+(?:/[^\]/\s]+)+)\]\s*$`)
+
+// rangePatterns match the numeric ranges given in descriptions, e.g. [0.0 - 1.0] or "clamped to the range -1.0 to 1.0"
+var rangePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\[\s*(-?\d+(?:\.\d+)?)\s*-\s*(-?\d+(?:\.\d+)?)\s*\]`),
+	regexp.MustCompile(`range (-?\d+(?:\.\d+)?) to (-?\d+(?:\.\d+)?)`),
+}
+
+// minimumPattern matches lower bounds given in descriptions, e.g. "Minimum 1.0"
+var minimumPattern = regexp.MustCompile(`(?i)\bminimum (-?\d+(?:\.\d+)?)\b`)
+
+// deriveConstraints sets the allowed values and the bounds of the variable from what its description says.
+// Lists of numbers, such as [0/1/2] for an int, become bounds.
+func deriveConstraints(v *parser_data.VariableDefinition) {
+	description := strings.TrimSpace(v.Description)
+	numeric := v.Type == "int" || v.Type == "float" || v.Type == "floatvalue"
+
+	if match := enumPattern.FindStringSubmatch(description); match != nil {
+		values := strings.Split(match[1], "/")
+		if bounds, ok := numbers(values); ok && numeric {
+			v.Min, v.Max = &bounds[0], &bounds[len(bounds)-1]
+		} else if !numeric {
+			v.Enum = values
+		}
+	}
+
+	if !numeric || v.Min != nil {
+		return
+	}
+	for _, pattern := range rangePatterns {
+		match := pattern.FindStringSubmatch(description)
+		if match == nil {
+			continue
+		}
+		if bounds, ok := numbers(match[1:]); ok {
+			v.Min, v.Max = &bounds[0], &bounds[1]
+			return
+		}
+	}
+	if match := minimumPattern.FindStringSubmatch(description); match != nil {
+		if min, err := strconv.ParseFloat(match[1], 64); err == nil {
+			v.Min = &min
+		}
+	}
+}
+
+// numbers parses all of values as numbers, sorted in increasing order. ok is false if any of them is not a number.
+func numbers(values []string) (parsed []float64, ok bool) {
+	for _, value := range values {
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, false
+		}
+		parsed = append(parsed, n)
+	}
+	slices.Sort(parsed)
+	return parsed, true
+}
@@ -0,0 +1,48 @@
+package wiki
+
+import (
+	"slices"
+	"testing"
+
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+)
+
+func TestDeriveConstraints(t *testing.T) {
+	bound := func(n float64) *float64 { return &n }
+	tests := []struct {
+		variable parser_data.VariableDefinition
+		enum     []string
+		min, max *float64
+	}{
+		{variable: parser_data.VariableDefinition{Type: "str", Description: "which layout to use. [dwindle/master]"}, enum: []string{"dwindle", "master"}},
+		{variable: parser_data.VariableDefinition{Type: "int", Description: "See the note below. [0/1/2/3]"}, min: bound(0), max: bound(3)},
+		{variable: parser_data.VariableDefinition{Type: "int", Description: "-1 means \"random\". [-1/0/1/2]"}, min: bound(-1), max: bound(2)},
+		{variable: parser_data.VariableDefinition{Type: "float", Description: "opacity of active windows. [0.0 - 1.0]"}, min: bound(0), max: bound(1)},
+		{variable: parser_data.VariableDefinition{Type: "float", Description: "Value is clamped to the range -1.0 to 1.0. libinput#pointer-acceleration"}, min: bound(-1), max: bound(1)},
+		{variable: parser_data.VariableDefinition{Type: "float", Description: "the zoom factor. Minimum 1.0"}, min: bound(1)},
+		{variable: parser_data.VariableDefinition{Type: "int", Description: "gaps between windows"}},
+		{variable: parser_data.VariableDefinition{Type: "str / int", Description: "which monitor to use [DP-1/HDMI-A-1]"}, enum: []string{"DP-1", "HDMI-A-1"}},
+	}
+
+	for _, test := range tests {
+		v := test.variable
+		deriveConstraints(&v)
+		if !slices.Equal(v.Enum, test.enum) {
+			t.Errorf("%q: expected enum %v, got %v", v.Description, test.enum, v.Enum)
+		}
+		if !sameBound(v.Min, test.min) || !sameBound(v.Max, test.max) {
+			t.Errorf("%q: expected range %v - %v, got %v - %v", v.Description, describeBound(test.min), describeBound(test.max), describeBound(v.Min), describeBound(v.Max))
+		}
+	}
+}
+
+func sameBound(a, b *float64) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+func describeBound(n *float64) any {
+	if n == nil {
+		return "none"
+	}
+	return *n
+}
@@ -0,0 +1,106 @@
+package wiki
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"github.com/metal3d/go-slugify"
+)
+
+// dispatcherPages are the wiki pages that have tables of dispatchers. Binds has the table of the dispatchers of mouse binds, which only adds resizewindow.
+var dispatcherPages = []string{"Dispatchers", "Dwindle-Layout", "Binds"}
+
+// undocumentedDispatchers are dispatchers that the wiki mentions, but that are not in any table of dispatchers
+var undocumentedDispatchers = []parser_data.DispatcherDefinition{
+	{
+		Name:                     "layoutmsg",
+		Description:              "sends a message to the current layout. The messages each layout understands are listed on the Dwindle-Layout and Master-Layout pages",
+		Params:                   "message",
+		DocumentationFile:        "Master-Layout",
+		DocumentationHeadingSlug: "dispatchers",
+	},
+	{
+		// Hyprland still accepts it as a dispatcher, as in the default configuration, but the wiki only lists it as a message of layoutmsg
+		Name:                     "togglesplit",
+		Description:              "toggles the split (top/side) of the current window, with the dwindle layout. `preserve_split` must be enabled for toggling to work",
+		Params:                   "none",
+		DocumentationFile:        "Dwindle-Layout",
+		DocumentationHeadingSlug: "layout-messages",
+	},
+}
+
+// parameterKindPatterns match the mentions of the kinds of parameters in the params column of the tables of dispatchers.
+// Possessives, as in "the window's internal state", are not mentions of a parameter.
+var parameterKindPatterns = map[parser_data.DispatcherParameterKind]*regexp.Regexp{
+	parser_data.ParameterWorkspace: regexp.MustCompile(`\bworkspace(?:$|[^'\w])`),
+	parser_data.ParameterWindow:    regexp.MustCompile(`\bwindow(?:$|[^'\w])`),
+	parser_data.ParameterDirection: regexp.MustCompile(`\bdirection\b`),
+	parser_data.ParameterMonitor:   regexp.MustCompile(`\bmonitors?\b`),
+	parser_data.ParameterToggle:    regexp.MustCompile(`\bon\b.*\boff\b`),
+	parser_data.ParameterCommand:   regexp.MustCompile(`\bcommand\b`),
+}
+
+// relrefLinkPattern matches links to other parts of the wiki that go through a Hugo shortcode, which are not rendered, e.g. [below]({{< relref "#executing-with-rules" >}})
+var relrefLinkPattern = regexp.MustCompile(`\[([^\]]*)\]\(\{\{<[^)]*>\}\}\)`)
+
+// parseDispatchersMarkdown returns the dispatchers listed in the tables of a wiki page
+func parseDispatchersMarkdown(source []byte, page string) ([]parser_data.DispatcherDefinition, error) {
+	document, err := markdownToHTML(source)
+	if err != nil {
+		return nil, err
+	}
+	dispatchers := make([]parser_data.DispatcherDefinition, 0)
+	for _, table := range document.FindAll("table") {
+		header := lowercased(tableHeaderCells(table))
+		if !arraysEqual(header, []string{"dispatcher", "description", "params"}) && !arraysEqual(header, []string{"name", "description", "params"}) {
+			continue
+		}
+
+		heading, found := backtrackToNearestHeader(table)
+		if !found {
+			return nil, fmt.Errorf("a table of dispatchers is not under any heading")
+		}
+		slug := slugify.Marshal(strings.TrimSpace(heading.FullText()), true)
+		for _, cells := range tableRows(table, 3) {
+			for i, cell := range cells {
+				cells[i] = strings.TrimSpace(relrefLinkPattern.ReplaceAllString(cell, "$1"))
+			}
+			dispatchers = append(dispatchers, parser_data.DispatcherDefinition{
+				Name:                     cells[0],
+				Description:              cells[1],
+				Params:                   cells[2],
+				ParameterKinds:           parameterKinds(cells[2]),
+				DocumentationFile:        page,
+				DocumentationHeadingSlug: slug,
+			})
+		}
+	}
+	return dispatchers, nil
+}
+
+// parameterKinds returns the kinds of parameters mentioned in the params column of a dispatcher, in the order they are mentioned
+func parameterKinds(params string) []parser_data.DispatcherParameterKind {
+	positions := make(map[parser_data.DispatcherParameterKind]int)
+	kinds := make([]parser_data.DispatcherParameterKind, 0)
+	for kind, pattern := range parameterKindPatterns {
+		if match := pattern.FindStringIndex(params); match != nil {
+			positions[kind] = match[0]
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Slice(kinds, func(i, j int) bool {
+		return positions[kinds[i]] < positions[kinds[j]]
+	})
+	return kinds
+}
+
+func lowercased(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		result = append(result, strings.ToLower(value))
+	}
+	return result
+}
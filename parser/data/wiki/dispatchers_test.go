@@ -0,0 +1,60 @@
+package wiki
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+)
+
+func TestParseDispatchersMarkdown(t *testing.T) {
+	dispatchers, err := parseDispatchersMarkdown([]byte(heredoc.Doc(`
+		## Parameter explanation
+
+		| Param type | Description |
+		| --- | --- |
+		| direction | l r u d |
+
+		## List of Dispatchers
+
+		| Dispatcher | Description | Params |
+		| --- | --- | --- |
+		| exec | executes a shell command | command (supports rules, see [below]({{< relref "#executing-with-rules" >}})) |
+		| movetoworkspace | moves the focused window to a workspace | workspace OR `+"`workspace,window`"+` for a specific window |
+		| killactive | closes (not kills) the active window | none |
+	`)), "Dispatchers")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []parser_data.DispatcherDefinition{
+		{Name: "exec", Description: "executes a shell command", Params: "command (supports rules, see below)", ParameterKinds: []parser_data.DispatcherParameterKind{parser_data.ParameterCommand}},
+		{Name: "movetoworkspace", Description: "moves the focused window to a workspace", Params: "workspace OR workspace,window for a specific window", ParameterKinds: []parser_data.DispatcherParameterKind{parser_data.ParameterWorkspace, parser_data.ParameterWindow}},
+		{Name: "killactive", Description: "closes (not kills) the active window", Params: "none", ParameterKinds: []parser_data.DispatcherParameterKind{}},
+	}
+	if len(dispatchers) != len(expected) {
+		t.Fatalf("expected %d dispatchers, got %d: %v", len(expected), len(dispatchers), dispatchers)
+	}
+	for i, d := range dispatchers {
+		if d.Name != expected[i].Name || d.Description != expected[i].Description || d.Params != expected[i].Params || !slices.Equal(d.ParameterKinds, expected[i].ParameterKinds) {
+			t.Errorf("expected %+v, got %+v", expected[i], d)
+		}
+		if d.DocumentationLink() != "https://wiki.hyprland.org/Configuring/Dispatchers/#list-of-dispatchers" {
+			t.Errorf("unexpected documentation link %s", d.DocumentationLink())
+		}
+	}
+}
+
+func TestParameterKinds(t *testing.T) {
+	tests := map[string][]parser_data.DispatcherParameterKind{
+		"on, off, or toggle. For specific monitor add monitor name after a space":                     {parser_data.ParameterToggle, parser_data.ParameterMonitor},
+		"direction or mon: and a monitor":                                                             {parser_data.ParameterDirection, parser_data.ParameterMonitor},
+		"2 - fullscreen (same as fullscreen except doesn't alter window's internal fullscreen state)": {},
+	}
+	for params, expected := range tests {
+		if kinds := parameterKinds(params); !slices.Equal(kinds, expected) {
+			t.Errorf("%q: expected %v, got %v", params, expected, kinds)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package wiki
+
+import (
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+)
+
+// GoSource returns the source of a Go file of the parser_data package that declares embeddedDocumentation, the documentation the package loads on startup
+func GoSource(documentation parser_data.Documentation) ([]byte, error) {
+	var out strings.Builder
+	out.WriteString("// Code generated by parser/data/generate from the wiki pages. DO NOT EDIT.\n\n")
+	out.WriteString("package parser_data\n\n")
+	out.WriteString("var embeddedDocumentation = Documentation{\n")
+
+	fmt.Fprintf(&out, "Versions: %#v,\n", documentation.Versions)
+	out.WriteString("Sections: ")
+	writeSections(&out, documentation.Sections)
+	out.WriteString(",\n")
+
+	out.WriteString("Keywords: []KeywordDefinition{\n")
+	for _, kw := range documentation.Keywords {
+		out.WriteString("{\n")
+		fmt.Fprintf(&out, "Name: %s,\n", strconv.Quote(kw.Name))
+		fmt.Fprintf(&out, "Description: %s,\n", strconv.Quote(kw.Description))
+		fmt.Fprintf(&out, "DocumentationHeadingSlug: %s,\n", strconv.Quote(kw.DocumentationHeadingSlug))
+		fmt.Fprintf(&out, "DocumentationFile: %s,\n", strconv.Quote(kw.DocumentationFile))
+		if len(kw.Flags) > 0 {
+			fmt.Fprintf(&out, "Flags: %#v,\n", kw.Flags)
+		}
+		out.WriteString("Arguments: []KeywordArgument{\n")
+		for _, arg := range kw.Arguments {
+			fmt.Fprintf(&out, "{Name: %s, Type: %s", strconv.Quote(arg.Name), strconv.Quote(arg.Type))
+			if arg.Description != "" {
+				fmt.Fprintf(&out, ", Description: %s", strconv.Quote(arg.Description))
+			}
+			if arg.Optional {
+				out.WriteString(", Optional: true")
+			}
+			if arg.Rest {
+				out.WriteString(", Rest: true")
+			}
+			out.WriteString("},\n")
+		}
+		out.WriteString("},\n")
+		out.WriteString("},\n")
+	}
+	out.WriteString("},\n")
+
+	out.WriteString("Dispatchers: []DispatcherDefinition{\n")
+	for _, d := range documentation.Dispatchers {
+		fmt.Fprintf(&out, "{Name: %s, Description: %s, Params: %s", strconv.Quote(d.Name), strconv.Quote(d.Description), strconv.Quote(d.Params))
+		if len(d.ParameterKinds) > 0 {
+			kinds := make([]string, 0, len(d.ParameterKinds))
+			for _, kind := range d.ParameterKinds {
+				kinds = append(kinds, strconv.Quote(string(kind)))
+			}
+			fmt.Fprintf(&out, ", ParameterKinds: []DispatcherParameterKind{%s}", strings.Join(kinds, ", "))
+		}
+		fmt.Fprintf(&out, ", DocumentationFile: %s, DocumentationHeadingSlug: %s},\n", strconv.Quote(d.DocumentationFile), strconv.Quote(d.DocumentationHeadingSlug))
+	}
+	out.WriteString("},\n")
+
+	out.WriteString("}\n")
+	return format.Source([]byte(out.String()))
+}
+
+func writeSections(out *strings.Builder, sections []parser_data.SectionDefinition) {
+	out.WriteString("[]SectionDefinition{\n")
+	for _, section := range sections {
+		out.WriteString("{\n")
+		fmt.Fprintf(out, "Path: %#v,\n", section.Path)
+		if section.Subsections != nil {
+			out.WriteString("Subsections: ")
+			writeSections(out, section.Subsections)
+			out.WriteString(",\n")
+		}
+		if section.Variables != nil {
+			out.WriteString("Variables: []VariableDefinition{\n")
+			for _, v := range section.Variables {
+				fmt.Fprintf(out, "{Name: %s, Description: %s, Type: %s, Default: %s", strconv.Quote(v.Name), strconv.Quote(v.Description), strconv.Quote(v.Type), strconv.Quote(v.Default))
+				if v.AddedIn != "" {
+					fmt.Fprintf(out, ", AddedIn: %s", strconv.Quote(v.AddedIn))
+				}
+				if v.RemovedIn != "" {
+					fmt.Fprintf(out, ", RemovedIn: %s", strconv.Quote(v.RemovedIn))
+				}
+				if v.Enum != nil {
+					fmt.Fprintf(out, ", Enum: %#v", v.Enum)
+				}
+				if v.Min != nil {
+					fmt.Fprintf(out, ", Min: bound(%s)", formatNumber(*v.Min))
+				}
+				if v.Max != nil {
+					fmt.Fprintf(out, ", Max: bound(%s)", formatNumber(*v.Max))
+				}
+				out.WriteString("},\n")
+			}
+			out.WriteString("},\n")
+		}
+		out.WriteString("},\n")
+	}
+	out.WriteString("}")
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
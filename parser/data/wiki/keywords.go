@@ -0,0 +1,124 @@
+package wiki
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"github.com/metal3d/go-slugify"
+)
+
+// keywordPages are the wiki pages where keywords are looked for, in order: a keyword is documented by the first heading it is used under
+var keywordPages = []string{"Keywords", "Binds", "Monitors", "Animations", "Window-Rules", "Workspace-Rules", "Environment-variables"}
+
+// notKeywords are names that the wiki assigns to in its examples, but that are not keywords
+var notKeywords = []string{"someoption", "a"}
+
+// keywordLinePattern matches the lines of code that use a keyword, as in bind = SUPER, Q, killactive or bind[flags]=...
+// Lines that start with whitespace are in sections, and so are assignments to variables.
+var keywordLinePattern = regexp.MustCompile(`^([a-z][a-z0-9-]*)(\[flags\])?\s*=\s*(.*)$`)
+
+// inlineCodePattern matches code spans of markdown
+var inlineCodePattern = regexp.MustCompile("`([^`]+)`")
+
+// flagLinePattern matches the lines that describe a flag, as in "l -> locked, will also work when an input inhibitor is active."
+var flagLinePattern = regexp.MustCompile(`^([a-z]) -> `)
+
+// signaturePattern matches the arguments of a line that shows the syntax of a keyword instead of an example, as in NAME,ONOFF,SPEED,CURVE[,STYLE]
+var signaturePattern = regexp.MustCompile(`^\[?[A-Za-z][A-Za-z0-9_]*\]?$`)
+
+// scrapeKeywords returns the keywords documented by the pages of keywordPages, before parser_data.OverrideKeywords completes them.
+// read returns the contents of a page, and variableNames are the names of every variable.
+func scrapeKeywords(read func(page string) ([]byte, error), variableNames map[string]bool) ([]parser_data.KeywordDefinition, error) {
+	scraped := make([]parser_data.KeywordDefinition, 0)
+	for _, page := range keywordPages {
+		content, err := read(page)
+		if err != nil {
+			return nil, fmt.Errorf("while reading documentation of keywords: %w", err)
+		}
+		for _, kw := range parseKeywordsMarkdown(content, page, variableNames) {
+			if !slices.ContainsFunc(scraped, func(k parser_data.KeywordDefinition) bool { return k.Name == kw.Name }) {
+				scraped = append(scraped, kw)
+			}
+		}
+	}
+	return scraped, nil
+}
+
+// parseKeywordsMarkdown returns the keywords used in the code of a wiki page, in the order they first appear.
+// known are the names of the variables, which are assigned to in examples the same way keywords are.
+func parseKeywordsMarkdown(source []byte, page string, known map[string]bool) []parser_data.KeywordDefinition {
+	keywords := make([]parser_data.KeywordDefinition, 0)
+	index := make(map[string]int)
+	heading, flagsOf := "", ""
+
+	use := func(code string) {
+		match := keywordLinePattern.FindStringSubmatch(code)
+		if match == nil || known[match[1]] || slices.Contains(notKeywords, match[1]) {
+			return
+		}
+		name := match[1]
+		if match[2] != "" {
+			// The flags of the keyword are listed after its syntax, under the same heading
+			flagsOf = name
+		}
+		if _, ok := index[name]; ok {
+			return
+		}
+		index[name] = len(keywords)
+		keywords = append(keywords, parser_data.KeywordDefinition{
+			Name:                     name,
+			DocumentationFile:        page,
+			DocumentationHeadingSlug: slugify.Marshal(heading, true),
+			Arguments:                signature(match[3]),
+		})
+	}
+
+	inCode := false
+	scanner := bufio.NewScanner(bytes.NewReader(source))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "```"):
+			inCode = !inCode
+		case inCode:
+			if match := flagLinePattern.FindStringSubmatch(line); match != nil && flagsOf != "" {
+				keywords[index[flagsOf]].Flags = append(keywords[index[flagsOf]].Flags, match[1])
+				continue
+			}
+			use(line)
+		case strings.HasPrefix(line, "#"):
+			heading = strings.TrimSpace(strings.TrimLeft(line, "#"))
+			flagsOf = ""
+		default:
+			for _, match := range inlineCodePattern.FindAllStringSubmatch(line, -1) {
+				use(match[1])
+			}
+		}
+	}
+	return keywords
+}
+
+// signature returns the arguments of a keyword from the value of a line that uses it, if that line shows the syntax of the keyword, as in NAME,X0,Y0,X1,Y1.
+// Otherwise, the keyword takes the rest of the line as a single argument.
+func signature(value string) []parser_data.KeywordArgument {
+	// Optional arguments are written as in CURVE[,STYLE]
+	names := strings.Split(strings.ReplaceAll(value, "[,", ",["), ",")
+	arguments := make([]parser_data.KeywordArgument, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if !signaturePattern.MatchString(name) {
+			return []parser_data.KeywordArgument{{Name: "value", Type: "str", Rest: true}}
+		}
+		arguments = append(arguments, parser_data.KeywordArgument{
+			Name:     strings.ToLower(strings.Trim(name, "[]")),
+			Type:     "str",
+			Optional: strings.HasPrefix(name, "["),
+		})
+	}
+	return arguments
+}
@@ -0,0 +1,128 @@
+package wiki
+
+import (
+	"io/fs"
+	"slices"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+)
+
+// TestKeywordOverrides checks that KeywordOverrides only correct keywords that the embedded wiki snapshots document, and that UndocumentedKeywords are not documented by any of them
+func TestKeywordOverrides(t *testing.T) {
+	variableNames := make(map[string]bool)
+	walkVariables(parser_data.Sections, func(v parser_data.VariableDefinition) {
+		variableNames[v.Name] = true
+	})
+
+	for _, version := range EmbeddedVersions() {
+		pages := EmbeddedPages(version)
+		scraped, err := scrapeKeywords(func(page string) ([]byte, error) {
+			return fs.ReadFile(pages, page+".md")
+		}, variableNames)
+		if err != nil {
+			t.Fatalf("%s: %s", version, err)
+		}
+
+		find := func(name string) (parser_data.KeywordDefinition, bool) {
+			i := slices.IndexFunc(scraped, func(k parser_data.KeywordDefinition) bool { return k.Name == name })
+			if i == -1 {
+				return parser_data.KeywordDefinition{}, false
+			}
+			return scraped[i], true
+		}
+
+		for _, override := range parser_data.KeywordOverrides {
+			kw, found := find(override.Name)
+			if !found {
+				t.Errorf("%s: %s is overridden but not scraped, move it to UndocumentedKeywords", version, override.Name)
+				continue
+			}
+			for _, flag := range override.Flags {
+				if slices.Contains(kw.Flags, flag) {
+					t.Errorf("%s: flag %s of %s is already scraped, remove it from its override", version, flag, override.Name)
+				}
+			}
+			if override.DocumentationFile == kw.DocumentationFile && override.DocumentationHeadingSlug == kw.DocumentationHeadingSlug {
+				t.Errorf("%s: %s is already scraped from %s#%s, remove the heading from its override", version, override.Name, kw.DocumentationFile, kw.DocumentationHeadingSlug)
+			}
+		}
+		for _, undocumented := range parser_data.UndocumentedKeywords {
+			if _, found := find(undocumented.Name); found {
+				t.Errorf("%s: %s is scraped, move it to KeywordOverrides", version, undocumented.Name)
+			}
+		}
+	}
+}
+
+func TestParseKeywordsMarkdown(t *testing.T) {
+	keywords := parseKeywordsMarkdown([]byte(heredoc.Doc(`
+		## Basic
+
+		`+"```ini"+`
+		bind=MODS,key,dispatcher,params
+		`+"```"+`
+
+		Then `+"`submap=resize`"+` enters the submap, and `+"`gaps_in = 5`"+` is an option.
+
+		## Bind flags
+
+		`+"```ini"+`
+		bind[flags]=...
+		bindrl=MOD,KEY,exec,amongus
+		`+"```"+`
+
+		Flags:
+
+		`+"```ini"+`
+		l -> locked, will also work when an input inhibitor (e.g. a lockscreen) is active.
+		r -> release, will trigger on release of a key.
+		`+"```"+`
+
+		## Curves
+
+		`+"```ini"+`
+		bezier=NAME,X0,Y0,X1,Y1
+		animation=NAME,ONOFF,SPEED,CURVE[,STYLE]
+		device {
+		    name = my-mouse
+		}
+		someoption = blah
+		`+"```"+`
+	`)), "Binds", map[string]bool{"gaps_in": true})
+
+	names := make([]string, 0, len(keywords))
+	for _, kw := range keywords {
+		names = append(names, kw.Name)
+	}
+	if !slices.Equal(names, []string{"bind", "submap", "bindrl", "bezier", "animation"}) {
+		t.Fatalf("unexpected keywords %v", names)
+	}
+
+	bind := keywords[0]
+	if bind.DocumentationHeadingSlug != "basic" || !slices.Equal(bind.Flags, []string{"l", "r"}) {
+		t.Errorf("unexpected bind %+v", bind)
+	}
+	if len(bind.Arguments) != 4 || bind.Arguments[0].Name != "mods" {
+		t.Errorf("unexpected arguments of bind %+v", bind.Arguments)
+	}
+
+	if submap := keywords[1]; len(submap.Arguments) != 1 || submap.Arguments[0].Name != "resize" {
+		t.Errorf("unexpected arguments of submap %+v", submap.Arguments)
+	}
+
+	animation := keywords[4]
+	if len(animation.Arguments) != 5 || animation.Arguments[3].Optional || !animation.Arguments[4].Optional || animation.Arguments[4].Name != "style" {
+		t.Errorf("unexpected arguments of animation %+v", animation.Arguments)
+	}
+	if animation.DocumentationHeadingSlug != "curves" {
+		t.Errorf("unexpected heading of animation %s", animation.DocumentationHeadingSlug)
+	}
+}
+
+func TestSignature(t *testing.T) {
+	if arguments := signature("~/.config/hypr/myColors.conf"); len(arguments) != 1 || arguments[0].Name != "value" || !arguments[0].Rest {
+		t.Errorf("examples should give a single argument, got %+v", arguments)
+	}
+}
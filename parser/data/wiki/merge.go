@@ -0,0 +1,106 @@
+package wiki
+
+import (
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+)
+
+// Snapshot is the documentation scraped from the wiki pages of a Hyprland version
+type Snapshot struct {
+	Version       string
+	Documentation parser_data.Documentation
+}
+
+// Merge merges the documentation of several Hyprland versions, ordered from oldest to newest, into one that has every section, variable, keyword and dispatcher of all of them.
+// Variables are described as in the newest version that has them, and their AddedIn and RemovedIn are set from the first and last versions that have them.
+func Merge(snapshots []Snapshot) parser_data.Documentation {
+	merged := parser_data.Documentation{
+		Versions: make([]string, 0, len(snapshots)),
+	}
+	if len(snapshots) == 0 {
+		return merged
+	}
+
+	// Sections of the newest version come first, in their order, followed by the ones that only older versions have
+	sectionPaths := make([]string, 0)
+	sections := make(map[string]parser_data.SectionDefinition)
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		for _, section := range snapshots[i].Documentation.Sections {
+			if _, ok := sections[section.PathString()]; !ok {
+				sectionPaths = append(sectionPaths, section.PathString())
+				sections[section.PathString()] = parser_data.SectionDefinition{Path: section.Path, Variables: make([]parser_data.VariableDefinition, 0)}
+			}
+		}
+	}
+
+	for _, path := range sectionPaths {
+		section := sections[path]
+		// versions[name] are the indices of the snapshots that have the variable
+		names := make([]string, 0)
+		versions := make(map[string][]int)
+		definitions := make(map[string]parser_data.VariableDefinition)
+		for i := len(snapshots) - 1; i >= 0; i-- {
+			for _, v := range variablesOf(snapshots[i].Documentation, path) {
+				if _, ok := versions[v.Name]; !ok {
+					names = append(names, v.Name)
+					definitions[v.Name] = v
+				}
+				versions[v.Name] = append(versions[v.Name], i)
+			}
+		}
+
+		for _, name := range names {
+			v := definitions[name]
+			newest, oldest := versions[name][0], versions[name][len(versions[name])-1]
+			if oldest > 0 {
+				v.AddedIn = snapshots[oldest].Version
+			}
+			if newest < len(snapshots)-1 {
+				v.RemovedIn = snapshots[newest+1].Version
+			}
+			section.Variables = append(section.Variables, v)
+		}
+		sections[path] = section
+	}
+
+	for _, path := range sectionPaths {
+		merged.Sections = append(merged.Sections, sections[path])
+	}
+	for i, section := range merged.Sections {
+		if len(section.Path) == 1 {
+			merged.Sections[i] = attachSubsections(section, merged.Sections)
+		}
+	}
+
+	for _, snapshot := range snapshots {
+		merged.Versions = append(merged.Versions, snapshot.Version)
+	}
+
+	// Keywords and dispatchers of the newest version come first, described as in that version, followed by the ones that only older versions have
+	keywords := make(map[string]bool)
+	dispatchers := make(map[string]bool)
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		for _, keyword := range snapshots[i].Documentation.Keywords {
+			if !keywords[keyword.Name] {
+				keywords[keyword.Name] = true
+				merged.Keywords = append(merged.Keywords, keyword)
+			}
+		}
+		for _, dispatcher := range snapshots[i].Documentation.Dispatchers {
+			if !dispatchers[dispatcher.Name] {
+				dispatchers[dispatcher.Name] = true
+				merged.Dispatchers = append(merged.Dispatchers, dispatcher)
+			}
+		}
+	}
+	return merged
+}
+
+// variablesOf returns the variables of the section at path in documentation
+func variablesOf(documentation parser_data.Documentation, path string) []parser_data.VariableDefinition {
+	for _, section := range documentation.Sections {
+		if section.PathString() == path {
+			return section.Variables
+		}
+	}
+	return nil
+}
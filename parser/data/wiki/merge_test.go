@@ -0,0 +1,57 @@
+package wiki
+
+import (
+	"testing"
+
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+)
+
+func TestMerge(t *testing.T) {
+	snapshot := func(version string, general []string, misc []string) Snapshot {
+		documentation := parser_data.Documentation{Versions: []string{version}}
+		for _, section := range []struct {
+			name      string
+			variables []string
+		}{{"General", general}, {"Misc", misc}} {
+			if section.variables == nil {
+				continue
+			}
+			def := parser_data.SectionDefinition{Path: []string{section.name}}
+			for _, name := range section.variables {
+				def.Variables = append(def.Variables, parser_data.VariableDefinition{Name: name, Description: name + " in " + version})
+			}
+			documentation.Sections = append(documentation.Sections, def)
+		}
+		return Snapshot{Version: version, Documentation: documentation}
+	}
+
+	merged := Merge([]Snapshot{
+		snapshot("v0.39.0", []string{"kept", "removed"}, []string{"gone"}),
+		snapshot("v0.40.0", []string{"kept", "removed", "added"}, nil),
+		snapshot("v0.41.0", []string{"kept", "added"}, nil),
+	})
+
+	if len(merged.Versions) != 3 || merged.Versions[2] != "v0.41.0" {
+		t.Errorf("unexpected versions %v", merged.Versions)
+	}
+	if len(merged.Sections) != 2 || merged.Sections[0].PathString() != "general" || merged.Sections[1].PathString() != "misc" {
+		t.Fatalf("unexpected sections %+v", merged.Sections)
+	}
+
+	expected := map[string]parser_data.VariableDefinition{
+		"kept":    {Description: "kept in v0.41.0"},
+		"added":   {Description: "added in v0.41.0", AddedIn: "v0.40.0"},
+		"removed": {Description: "removed in v0.40.0", RemovedIn: "v0.41.0"},
+	}
+	if len(merged.Sections[0].Variables) != len(expected) {
+		t.Fatalf("unexpected variables %+v", merged.Sections[0].Variables)
+	}
+	for _, v := range merged.Sections[0].Variables {
+		if v.Description != expected[v.Name].Description || v.AddedIn != expected[v.Name].AddedIn || v.RemovedIn != expected[v.Name].RemovedIn {
+			t.Errorf("unexpected %s: %+v", v.Name, v)
+		}
+	}
+	if v := merged.Sections[1].Variables; len(v) != 1 || v[0].RemovedIn != "v0.40.0" {
+		t.Errorf("expected misc:gone to be removed in v0.40.0, got %+v", v)
+	}
+}
@@ -0,0 +1,432 @@
+// Package wiki scrapes the Hyprland wiki pages to get the documentation of every section, variable and keyword.
+// It is used by parser/data/generate, which stores the result in the parser_data package so that it does not need to be scraped at runtime,
+// and by hyprls to load the documentation from a local checkout of the wiki, whose pages may not be in the shape the scraper expects.
+package wiki
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/anaskhan96/soup"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"github.com/metal3d/go-slugify"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+
+	html2markdown "github.com/evorts/html-to-markdown"
+	"golang.org/x/net/html"
+)
+
+var html2md = html2markdown.NewConverter("wiki.hyprlang.org", true, &html2markdown.Options{})
+var md = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+func debug(msg string, fmtArgs ...any) {
+	// fmt.Fprintf(os.Stderr, msg, fmtArgs...)
+}
+
+//go:embed sources
+var sources embed.FS
+
+var undocumentedGeneralSectionVariables = []parser_data.VariableDefinition{
+	{
+		Name:        "autogenerated",
+		Description: "Whether this configuration was autogenerated",
+		Type:        "bool",
+		Default:     "1",
+	},
+}
+
+func init() {
+	html2md.AddRules(html2markdown.Rule{
+		Filter: []string{"a"},
+		Replacement: func(content string, selec *goquery.Selection, options *html2markdown.Options) *string {
+			href, _ := selec.Attr("href")
+			if strings.HasPrefix(href, "../") {
+				href = strings.Replace(href, "../", "https://wiki.hyprland.org/Configuring/", 1)
+			}
+			result := fmt.Sprintf("[%s](%s)", content, href)
+			return html2markdown.String(result)
+		},
+	})
+}
+
+// EmbeddedVersions returns the Hyprland versions of the wiki snapshots embedded in the package, oldest first.
+// Each snapshot is a copy of hyprland-wiki/pages/Configuring at the tag of that version, in sources/<version>.
+// Only v0.40.0 is embedded for now: it is the copy the scraper started from, which documents misc:initial_workspace_tracking, added in v0.40.0, but not the cursor section of v0.41.0.
+// Until other snapshots are added with just wiki-snapshot, Merge cannot tell when variables were added.
+func EmbeddedVersions() []string {
+	entries, err := sources.ReadDir("sources")
+	if err != nil {
+		panic(err)
+	}
+	versions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			versions = append(versions, entry.Name())
+		}
+	}
+	parser_data.SortVersions(versions)
+	return versions
+}
+
+// EmbeddedPages returns the markdown files of the wiki's Configuring pages of the embedded snapshot of a Hyprland version
+func EmbeddedPages(version string) fs.FS {
+	pages, err := fs.Sub(sources, "sources/"+version)
+	if err != nil {
+		panic(err)
+	}
+	return pages
+}
+
+// ParseEmbedded scrapes every embedded wiki snapshot, and merges them into one documentation
+func ParseEmbedded() (parser_data.Documentation, error) {
+	snapshots := make([]Snapshot, 0)
+	for _, version := range EmbeddedVersions() {
+		documentation, err := Parse(EmbeddedPages(version))
+		if err != nil {
+			return parser_data.Documentation{}, fmt.Errorf("while scraping the wiki of %s: %w", version, err)
+		}
+		snapshots = append(snapshots, Snapshot{Version: version, Documentation: documentation})
+	}
+	return Merge(snapshots), nil
+}
+
+// Parse scrapes the markdown files of the wiki's Configuring pages in pages, such as the ones of hyprland-wiki/pages/Configuring
+func Parse(pages fs.FS) (parser_data.Documentation, error) {
+	read := func(page string) ([]byte, error) {
+		return fs.ReadFile(pages, page+".md")
+	}
+
+	variables, err := read("Variables")
+	if err != nil {
+		return parser_data.Documentation{}, err
+	}
+	masterLayout, err := read("Master-Layout")
+	if err != nil {
+		return parser_data.Documentation{}, err
+	}
+	dwindleLayout, err := read("Dwindle-Layout")
+	if err != nil {
+		return parser_data.Documentation{}, err
+	}
+
+	sections, err := parseDocumentationMarkdown(variables, 3)
+	if err != nil {
+		return parser_data.Documentation{}, fmt.Errorf("while scraping Variables: %w", err)
+	}
+	masterSections, err := parseDocumentationMarkdownWithRootSectionName(masterLayout, 2, "Master")
+	if err != nil {
+		return parser_data.Documentation{}, fmt.Errorf("while scraping Master-Layout: %w", err)
+	}
+	dwindleSections, err := parseDocumentationMarkdownWithRootSectionName(dwindleLayout, 2, "Dwindle")
+	if err != nil {
+		return parser_data.Documentation{}, fmt.Errorf("while scraping Dwindle-Layout: %w", err)
+	}
+	sections = append(sections, masterSections...)
+	sections = append(sections, dwindleSections...)
+	addVariableDefsOnSection(sections, "General", undocumentedGeneralSectionVariables)
+
+	variableNames := make(map[string]bool)
+	walkVariables(sections, func(v parser_data.VariableDefinition) {
+		variableNames[v.Name] = true
+	})
+	scraped, err := scrapeKeywords(read, variableNames)
+	if err != nil {
+		return parser_data.Documentation{}, err
+	}
+
+	keywords := parser_data.OverrideKeywords(scraped)
+	for i, kw := range keywords {
+		// Keywords that the wiki doesn't document yet are described by their override
+		if kw.Description != "" {
+			continue
+		}
+		content, err := read(kw.DocumentationFile)
+		if err != nil {
+			return parser_data.Documentation{}, fmt.Errorf("while reading documentation of %s: %w", kw.Name, err)
+		}
+
+		document, err := markdownToHTML(content)
+		if err != nil {
+			return parser_data.Documentation{}, fmt.Errorf("while reading documentation of %s: %w", kw.Name, err)
+		}
+		heading, found := findHeading(document, kw.DocumentationHeadingSlug)
+		if !found {
+			return parser_data.Documentation{}, fmt.Errorf("cannot find heading %s in %s", kw.DocumentationHeadingSlug, kw.DocumentationFile)
+		}
+		keywords[i].Description, _ = html2md.ConvertString(htmlBetweenHeadingAndNextHeading(heading, heading))
+	}
+
+	dispatchers := make([]parser_data.DispatcherDefinition, 0)
+	for _, page := range dispatcherPages {
+		content, err := read(page)
+		if err != nil {
+			return parser_data.Documentation{}, fmt.Errorf("while reading documentation of dispatchers: %w", err)
+		}
+		scraped, err := parseDispatchersMarkdown(content, page)
+		if err != nil {
+			return parser_data.Documentation{}, fmt.Errorf("while scraping %s: %w", page, err)
+		}
+		for _, dispatcher := range scraped {
+			// Dispatchers of mouse binds are also listed with the other dispatchers
+			if !slices.ContainsFunc(dispatchers, func(d parser_data.DispatcherDefinition) bool { return d.Name == dispatcher.Name }) {
+				dispatchers = append(dispatchers, dispatcher)
+			}
+		}
+	}
+	dispatchers = append(dispatchers, undocumentedDispatchers...)
+
+	return parser_data.Documentation{Sections: sections, Keywords: keywords, Dispatchers: dispatchers}, nil
+}
+
+func findHeading(document soup.Root, slug string) (soup.Root, bool) {
+	headings := make([]soup.Root, 0)
+	for _, t := range []string{"h1", "h2", "h3", "h4", "h5", "h6"} {
+		headings = append(headings, document.FindAll(t)...)
+	}
+	for _, h := range headings {
+		if id, ok := h.Attrs()["id"]; ok && id == slug {
+			return h, true
+		}
+		anchor := slugify.Marshal(strings.TrimSpace(h.Text()), true)
+		anchor = regexp.MustCompile(`^weight-%d+-title-`).ReplaceAllString(anchor, "")
+		if anchor == slug {
+			return h, true
+		}
+	}
+	return soup.Root{}, false
+}
+
+// walkVariables calls f on every variable of sections and of their subsections
+func walkVariables(sections []parser_data.SectionDefinition, f func(parser_data.VariableDefinition)) {
+	for _, section := range sections {
+		for _, v := range section.Variables {
+			f(v)
+		}
+		walkVariables(section.Subsections, f)
+	}
+}
+
+func addVariableDefsOnSection(sections []parser_data.SectionDefinition, sectionName string, variables []parser_data.VariableDefinition) {
+	for i, sec := range sections {
+		if sec.Name() != sectionName {
+			continue
+		}
+		sections[i].Variables = append(sections[i].Variables, variables...)
+	}
+}
+
+func parseDocumentationMarkdownWithRootSectionName(source []byte, headingRootLevel int, rootSectionName string) ([]parser_data.SectionDefinition, error) {
+	sections, err := parseDocumentationMarkdown(source, headingRootLevel)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sections {
+		sections[i].Path[0] = rootSectionName
+	}
+	return sections, nil
+}
+
+func markdownToHTML(source []byte) (soup.Root, error) {
+	var html bytes.Buffer
+	err := md.Convert(source, &html)
+	if err != nil {
+		return soup.Root{}, err
+	}
+
+	return soup.HTMLParse(html.String()), nil
+}
+
+func parseDocumentationMarkdown(source []byte, headingRootLevel int) (sections []parser_data.SectionDefinition, err error) {
+	document, err := markdownToHTML(source)
+	if err != nil {
+		return nil, err
+	}
+	tables := make([]soup.Root, 0)
+	for _, table := range document.FindAll("table") {
+		if arraysEqual(tableHeaderCells(table), []string{"name", "description", "type", "default"}) {
+			tables = append(tables, table)
+		}
+	}
+
+	// Only the headings of tables are sections: the others, such as "Custom accel profiles", only add explanations between sections
+	sectionHeadings := make(map[*html.Node]bool)
+	for _, table := range tables {
+		if heading, found := backtrackToNearestHeader(table); found {
+			sectionHeadings[heading.Pointer] = true
+		}
+	}
+
+	for _, table := range tables {
+		// fmt.Printf("Processing table %s\n", table.HTML())
+		path, err := tablePath(table, headingRootLevel, sectionHeadings)
+		if err != nil {
+			return nil, err
+		}
+		section := parser_data.SectionDefinition{
+			Path: path,
+		}
+		section.Variables = make([]parser_data.VariableDefinition, 0)
+		for _, cells := range tableRows(table, 4) {
+			variable := parser_data.VariableDefinition{
+				Name:        cells[0],
+				Description: cells[1],
+				Type:        cells[2],
+				Default:     cells[3],
+			}
+			deriveConstraints(&variable)
+			section.Variables = append(section.Variables, variable)
+		}
+		sections = append(sections, section)
+	}
+
+	for i, section := range sections {
+		if len(section.Path) == 1 {
+			sections[i] = attachSubsections(section, sections)
+		}
+	}
+	return sections, nil
+}
+
+func attachSubsections(s parser_data.SectionDefinition, sections []parser_data.SectionDefinition) parser_data.SectionDefinition {
+	// TODO make it work for recursively nested sections
+	s.Subsections = make([]parser_data.SectionDefinition, 0)
+	for _, section := range sections {
+		if len(section.Path) == 1 {
+			continue
+		}
+		if section.Path[0] == s.Name() {
+			debug("adding %s to %s\n", section.Name(), s.Name())
+			s.Subsections = append(s.Subsections, section)
+		}
+	}
+	return s
+}
+
+func tableHeaderCells(table soup.Root) []string {
+	headerCells := table.FindAll("th")
+	cells := make([]string, 0, len(headerCells))
+	for _, cell := range headerCells {
+		cells = append(cells, cell.FullText())
+	}
+	return cells
+}
+
+// tableRows returns the text of the cells of every row of the table's body that has the given number of columns
+func tableRows(table soup.Root, columns int) [][]string {
+	rows := make([][]string, 0)
+	for _, row := range table.FindAll("tr") {
+		cells := row.FindAll("td")
+		if len(cells) != columns {
+			continue
+		}
+		texts := make([]string, 0, columns)
+		for _, cell := range cells {
+			texts = append(texts, cell.FullText())
+		}
+		rows = append(rows, texts)
+	}
+	return rows
+}
+
+// tablePath returns the path of the section a table documents, from the headings of the sections above it down to the root heading level
+func tablePath(table soup.Root, headingRootLevel int, sectionHeadings map[*html.Node]bool) ([]string, error) {
+	heading, found := backtrackToNearestHeader(table)
+	if !found {
+		return nil, fmt.Errorf("a table is not under any heading")
+	}
+	return headingPath(heading, headingRootLevel, sectionHeadings)
+}
+
+func headingPath(heading soup.Root, headingRootLevel int, sectionHeadings map[*html.Node]bool) ([]string, error) {
+	if headingLevel(heading) <= headingRootLevel {
+		return []string{heading.FullText()}, nil
+	}
+	parent, found := parentSectionHeading(heading, sectionHeadings)
+	if !found {
+		return nil, fmt.Errorf("heading %q is not under the heading of a section of level %d or less", strings.TrimSpace(heading.FullText()), headingRootLevel)
+	}
+	path, err := headingPath(parent, headingRootLevel, sectionHeadings)
+	if err != nil {
+		return nil, err
+	}
+	return append(path, heading.FullText()), nil
+}
+
+// parentSectionHeading returns the nearest heading before heading that is of a lower level, and that is the heading of a section.
+// Headings of the same level, such as a previous subsection, and headings that are not sections are skipped.
+func parentSectionHeading(heading soup.Root, sectionHeadings map[*html.Node]bool) (soup.Root, bool) {
+	level := headingLevel(heading)
+	for {
+		previous, found := backtrackToNearestHeader(heading.FindPrevElementSibling())
+		if !found {
+			return soup.Root{}, false
+		}
+		if headingLevel(previous) < level && sectionHeadings[previous.Pointer] {
+			return previous, true
+		}
+		heading = previous
+	}
+}
+
+// backtrackToNearestHeader returns the nearest heading before element, which can be element itself
+func backtrackToNearestHeader(element soup.Root) (soup.Root, bool) {
+	if element.Error != nil || element.Pointer == nil {
+		return soup.Root{}, false
+	}
+	if isHeading(element) {
+		debug("-> returning from backtrack with %s\n", element.HTML())
+		return element, true
+	}
+	return backtrackToNearestHeader(element.FindPrevElementSibling())
+}
+
+func htmlBetweenHeadingAndNextHeading(heading soup.Root, element soup.Root) string {
+	next := element.FindNextElementSibling()
+	if next.Error != nil || next.Pointer == nil {
+		return ""
+	}
+	if isHeading(next) && headingLevel(next) == headingLevel(heading) {
+		return ""
+	}
+
+	defer func() {
+		if crash := recover(); crash != nil {
+			if os.Getenv("DEBUG") != "" {
+				fmt.Fprintf(os.Stderr, "Panic while rendering %s\n", next.HTML())
+			}
+		}
+	}()
+
+	rendered := next.HTML()
+
+	return rendered + htmlBetweenHeadingAndNextHeading(heading, next)
+}
+
+func isHeading(element soup.Root) bool {
+	return regexp.MustCompile(`^h[1-6]$`).MatchString(element.NodeValue)
+}
+
+// headingLevel returns the level of a heading, which isHeading must be true of
+func headingLevel(heading soup.Root) int {
+	return int(heading.NodeValue[1] - '0')
+}
+
+func arraysEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if strings.TrimSpace(v) != strings.TrimSpace(b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,97 @@
+package wiki
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestGeneratedDocumentationIsUpToDate(t *testing.T) {
+	documentation, err := ParseEmbedded()
+	if err != nil {
+		t.Fatal(err)
+	}
+	source, err := GoSource(documentation)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	generated, err := os.ReadFile("../documentation.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(source, generated) {
+		t.Error("parser/data/documentation.go does not match the wiki snapshots in parser/data/wiki/sources, run just parser-data to regenerate it")
+	}
+}
+
+func TestParseSectionPaths(t *testing.T) {
+	table := "| name | description | type | default |\n| --- | --- | --- | --- |\n| option | an option | int | 0 |\n\n"
+	sections, err := parseDocumentationMarkdown([]byte(
+		"## Sections\n\n### Input\n\n"+table+
+			"### Custom accel profiles\n\nExplanations.\n\n#### `accel_profile`\n\nMore explanations.\n\n"+
+			"#### Touchpad\n\n"+table+"#### Tablet\n\n"+table+
+			"### Group\n\n"+table+"#### Groupbar\n\n"+table,
+	), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paths := make([]string, 0, len(sections))
+	for _, section := range sections {
+		paths = append(paths, strings.Join(section.Path, ":"))
+	}
+	expected := []string{"Input", "Input:Touchpad", "Input:Tablet", "Group", "Group:Groupbar"}
+	if !slices.Equal(paths, expected) {
+		t.Errorf("expected sections %v, got %v", expected, paths)
+	}
+}
+
+func TestParseMalformedPages(t *testing.T) {
+	orphanTable := "| name | description | type | default |\n| --- | --- | --- | --- |\n| orphan | not under a heading | int | 0 |\n\n"
+	tests := map[string]struct {
+		page    string
+		replace func(string) string
+	}{
+		"table before any heading": {"Variables", func(s string) string { return orphanTable + s }},
+		"subsection without a section": {"Master-Layout", func(s string) string {
+			return "### Orphan\n\n" + orphanTable + s
+		}},
+		"missing page": {"Dwindle-Layout", nil},
+		"dispatchers table before any heading": {"Dispatchers", func(s string) string {
+			return "| Dispatcher | Description | Params |\n| --- | --- | --- |\n| orphan | not under a heading | none |\n\n" + s
+		}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pages := fstest.MapFS{}
+			embedded := EmbeddedPages(EmbeddedVersions()[0])
+			names, err := fs.Glob(embedded, "*.md")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, file := range names {
+				contents, err := fs.ReadFile(embedded, file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if file == test.page+".md" {
+					if test.replace == nil {
+						continue
+					}
+					contents = []byte(test.replace(string(contents)))
+				}
+				pages[file] = &fstest.MapFile{Data: contents}
+			}
+
+			if _, err := Parse(pages); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
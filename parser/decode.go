@@ -1,53 +1,333 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
+	"image/color"
 	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 
 	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"go.uber.org/multierr"
 )
 
+// ErrUnknownKey is wrapped by the DecodeError of assignments to options that don't exist
+var ErrUnknownKey = errors.New("unknown option")
+
+// ErrNoTypedForm is wrapped by the DecodeError of statements and options that Configuration cannot hold, such as source statements, plugin options or per-device sections
+var ErrNoTypedForm = errors.New("cannot be represented in a Configuration")
+
+// DecodeError is returned when an assignment cannot be decoded into the configuration
+type DecodeError struct {
+	// File is the path of the file that contains the assignment. It is only set when decoding a Graph.
+	File string
+	// Path is the full path of the option, e.g. decoration:blur:size
+	Path     string
+	Position Position
+	Err      error
+}
+
+func (e DecodeError) Error() string {
+	if e.File != "" {
+		return fmt.Sprintf("%s:%d: %s: %s", e.File, e.Position.Line+1, e.Path, e.Err)
+	}
+	return fmt.Sprintf("line %d: %s: %s", e.Position.Line+1, e.Path, e.Err)
+}
+
+func (e DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Decode decodes every assignment and keyword statement of the document into a Configuration, with custom variables expanded.
+// Options that the document does not set keep their default value.
+// Assignments and statements that cannot be decoded don't stop the decoding: all of their errors are returned, combined with multierr, along with the configuration decoded from the other assignments.
 func (root Section) Decode() (Configuration, error) {
-	config := Configuration{
-		CustomVariables: make(map[string]string, 0),
+	d := newDecoder(false)
+	for _, entry := range (&ConfigFile{Document: root}).Entries() {
+		d.decode(entry)
 	}
-	root.WalkCustomVariables(func(v *CustomVariable) {
-		config.CustomVariables[v.Key] = v.ValueRaw
-	})
+	return d.config, d.errs
+}
+
+// Decode decodes the effective configuration: the entries of every file of the graph, in the order Hyprland evaluates them.
+// Source statements are followed instead of being reported, and unbind statements remove the binds declared before them.
+// Errors are reported like with Section.Decode, along with the source statements that could not be followed.
+func (g *Graph) Decode() (Configuration, error) {
+	d := g.evaluate()
+	return d.config, d.errs
+}
+
+// evaluate decodes every entry of the graph, in the order Hyprland evaluates them
+func (g *Graph) evaluate() *decoder {
+	d := newDecoder(true)
+	g.Walk(d.decode)
+	for _, err := range g.Errors {
+		d.errs = multierr.Append(d.errs, err)
+	}
+	return d
+}
+
+// decoder decodes entries into a Configuration, one at a time and in order
+type decoder struct {
+	config    Configuration
+	errs      error
+	variables map[string]string
+	submap    string
+	// assignments are the assignments that successfully set each option, by full path
+	assignments map[string][]OptionAssignment
+	// effective is set when decoding a whole Graph
+	effective bool
+}
+
+func newDecoder(effective bool) *decoder {
+	return &decoder{
+		config:      DefaultConfiguration(),
+		variables:   make(map[string]string),
+		assignments: make(map[string][]OptionAssignment),
+		effective:   effective,
+	}
+}
 
-	for _, ass := range root.Assignments {
-		def := parser_data.FindVariableDefinitionInSection("General", ass.Key)
-		if def == nil {
-			availableKeys := make([]string, 0)
-			for _, v := range parser_data.FindSectionDefinitionByName("General").Variables {
-				availableKeys = append(availableKeys, v.Name)
+func (d *decoder) decode(entry Entry) {
+	switch {
+	case entry.Variable != nil:
+		raw := unescapeValue(entry.Variable.ValueRaw)
+		d.config.CustomVariables[entry.Variable.Key] = raw
+		d.variables[entry.Variable.Key] = ExpandVariables(raw, d.variables)
+	case entry.Assignment != nil:
+		path := OptionPath(entry.SectionPath, entry.Assignment.Key)
+		value := ExpandVariables(unescapeValue(entry.Assignment.ValueRaw), d.variables)
+		var err error
+		// Per-device sections configure devices by name, and plugins have their own options: neither are part of Configuration
+		if strings.HasPrefix(path[0], "device") || path[0] == "plugin" {
+			err = ErrNoTypedForm
+		} else {
+			err = setOption(reflect.ValueOf(&d.config).Elem(), path, value)
+		}
+		if err == nil {
+			key := strings.ToLower(strings.Join(path, ":"))
+			d.assignments[key] = append(d.assignments[key], OptionAssignment{
+				File:     entry.File.Path,
+				Position: entry.Assignment.Position,
+				Value:    strings.TrimSpace(value),
+			})
+		} else {
+			d.errs = multierr.Append(d.errs, DecodeError{
+				File:     entry.File.Path,
+				Path:     strings.Join(path, ":"),
+				Position: entry.Assignment.Position,
+				Err:      err,
+			})
+		}
+	case entry.Statement != nil:
+		stmt := *entry.Statement
+		stmt.ValueRaw = ExpandVariables(unescapeValue(stmt.ValueRaw), d.variables)
+		var err error
+		switch {
+		case stmt.Keyword == "submap":
+			d.submap = SubmapName(stmt)
+			if d.submap == SubmapReset {
+				d.submap = ""
+			} else if !slices.Contains(d.config.Submaps, d.submap) {
+				d.config.Submaps = append(d.config.Submaps, d.submap)
 			}
-			return Configuration{}, fmt.Errorf("unknown variable General > %s. Available keys are %v", ass.Key, availableKeys)
+		case d.effective && stmt.Keyword == "source":
+			// The graph already walks the sourced files right after this statement
+		case d.effective && stmt.Keyword == "unbind":
+			err = d.config.removeBinds(stmt)
+		default:
+			err = d.config.addStatement(stmt, d.submap)
+		}
+		if err != nil {
+			d.errs = multierr.Append(d.errs, DecodeError{
+				File:     entry.File.Path,
+				Path:     string(stmt.Keyword),
+				Position: stmt.Position,
+				Err:      err,
+			})
+		}
+	}
+}
+
+// unescapeValue returns the raw value of a line with the escaped ## turned back into #
+func unescapeValue(raw string) string {
+	return strings.ReplaceAll(raw, "##", "#")
+}
+
+// removeBinds removes the binds on the key combination of an unbind statement
+func (config *Configuration) removeBinds(stmt Statement) error {
+	unbind, err := ParseUnbind(stmt)
+	if err != nil {
+		return err
+	}
+	combo := unbind.NormalizedCombo()
+	config.Keybinds = slices.DeleteFunc(config.Keybinds, func(bind Bind) bool {
+		return bind.NormalizedCombo() == combo
+	})
+	return nil
+}
+
+// addStatement decodes the statement into the typed statements of the configuration. submap is the submap the statement is declared in, if any.
+// It returns ErrNoTypedForm for statements that the configuration cannot hold.
+func (config *Configuration) addStatement(stmt Statement, submap string) error {
+	var err error
+	switch {
+	case stmt.IsBind():
+		var bind Bind
+		bind, err = ParseBind(stmt)
+		bind.Submap = submap
+		config.Keybinds = appendIfValid(config.Keybinds, bind, err)
+	case stmt.Keyword == "monitor":
+		var monitor Monitor
+		monitor, err = ParseMonitor(stmt)
+		config.Monitors = appendIfValid(config.Monitors, monitor, err)
+	case stmt.Keyword == "workspace":
+		var rule WorkspaceRule
+		rule, err = ParseWorkspaceRule(stmt)
+		config.WorkspaceRules = appendIfValid(config.WorkspaceRules, rule, err)
+	case stmt.Keyword == "windowrule" || stmt.Keyword == "windowrulev2":
+		var rule WindowRule
+		rule, err = ParseWindowRule(stmt)
+		config.WindowRules = appendIfValid(config.WindowRules, rule, err)
+	case stmt.Keyword == "layerrule":
+		var rule LayerRule
+		rule, err = ParseLayerRule(stmt)
+		config.LayerRules = appendIfValid(config.LayerRules, rule, err)
+	case stmt.Keyword == "bezier":
+		var bezier Bezier
+		bezier, err = ParseBezier(stmt)
+		config.Beziers = appendIfValid(config.Beziers, bezier, err)
+	case stmt.Keyword == "animation":
+		var animation Animation
+		animation, err = ParseAnimation(stmt)
+		config.AnimationRules = appendIfValid(config.AnimationRules, animation, err)
+	case stmt.IsExec():
+		var exec Exec
+		exec, err = ParseExec(stmt)
+		config.Execs = appendIfValid(config.Execs, exec, err)
+	case stmt.IsEnv():
+		var env Env
+		env, err = ParseEnv(stmt)
+		config.Env = appendIfValid(config.Env, env, err)
+	default:
+		err = ErrNoTypedForm
+	}
+	return err
+}
+
+func appendIfValid[T any](list []T, item T, err error) []T {
+	if err != nil {
+		return list
+	}
+	return append(list, item)
+}
+
+// DefaultConfiguration returns the configuration Hyprland uses when no option is set
+func DefaultConfiguration() Configuration {
+	config := Configuration{
+		CustomVariables: make(map[string]string, 0),
+		Keybinds:        make([]Bind, 0),
+		Submaps:         make([]string, 0),
+		Monitors:        make([]Monitor, 0),
+		WorkspaceRules:  make([]WorkspaceRule, 0),
+		WindowRules:     make([]WindowRule, 0),
+		LayerRules:      make([]LayerRule, 0),
+		Beziers:         make([]Bezier, 0),
+		AnimationRules:  make([]Animation, 0),
+		Execs:           make([]Exec, 0),
+		Env:             make([]Env, 0),
+	}
+	for _, section := range parser_data.Sections {
+		for _, def := range section.Variables {
+			path := strings.Split(section.PathString(), parser_data.PathSeparator)
+			// Some defaults are descriptions rather than values (e.g. [[Auto]]), the option keeps its zero value in that case
+			setOption(reflect.ValueOf(&config).Elem(), append(path, def.Name), defaultValueRaw(def))
 		}
-		fmt.Printf("adding %s=%#v to .General", def.PascalCaseName(), ass.Value.GoValue())
-		setValue(&config, def.PascalCaseName(), ass.Value.GoValue())
 	}
+	return config
+}
 
-	return config, nil
+// defaultValueRaw returns the default value of the option, as it would be written in a configuration file
+func defaultValueRaw(def parser_data.VariableDefinition) string {
+	if strings.HasPrefix(def.Default, "[[") && strings.HasSuffix(def.Default, "]]") {
+		return ""
+	}
+	return def.Default
 }
 
-func setValue(obj any, field string, value any) {
-	ref := reflect.ValueOf(obj)
+// OptionPath returns the full path of the option set by an assignment to key in the section at sectionPath.
+// Keys can contain a path themselves, as in decoration:blur:size = 8. Options outside of any section are in the general section.
+func OptionPath(sectionPath []string, key string) []string {
+	path := append(append([]string{}, sectionPath...), strings.Split(key, ":")...)
+	if len(path) == 1 {
+		return []string{"general", key}
+	}
+	return path
+}
 
-	// if its a pointer, resolve its value
-	if ref.Kind() == reflect.Ptr {
-		ref = reflect.Indirect(ref)
+// setOption sets the option at path in the struct target to the value raw, decoded according to the type of the field
+func setOption(target reflect.Value, path []string, raw string) error {
+	field, ok := fieldByJSONName(target, path[0], len(path) > 1)
+	if !ok {
+		return ErrUnknownKey
 	}
+	if len(path) > 1 {
+		if field.Kind() != reflect.Struct {
+			return ErrUnknownKey
+		}
+		return setOption(field, path[1:], raw)
+	}
+	if field.Kind() == reflect.Struct && field.Type() != reflect.TypeOf(color.RGBA{}) && field.Type() != reflect.TypeOf(GradientValue{}) {
+		return ErrUnknownKey
+	}
+	return setValue(field, raw)
+}
 
-	if ref.Kind() == reflect.Interface {
-		ref = ref.Elem()
+// fieldByJSONName returns the field of the struct target whose JSON name is name. Sections names are compared case-insensitively.
+func fieldByJSONName(target reflect.Value, name string, isSection bool) (reflect.Value, bool) {
+	for i := 0; i < target.NumField(); i++ {
+		tag, _, _ := strings.Cut(target.Type().Field(i).Tag.Get("json"), ",")
+		if tag == name || (isSection && strings.EqualFold(tag, name)) {
+			return target.Field(i), true
+		}
 	}
+	return reflect.Value{}, false
+}
 
-	// should double check we now have a struct (could still be anything)
-	if ref.Kind() != reflect.Struct {
-		panic("cannot setValue on a non-struct")
+// setValue decodes raw according to the type of field and sets field to the result.
+// It returns an error if raw is not a valid value for that type.
+func setValue(field reflect.Value, raw string) error {
+	raw = strings.TrimSpace(raw)
+	var value any
+	var err error
+	switch field.Interface().(type) {
+	case int:
+		value, err = strconv.Atoi(raw)
+	case float32:
+		var f float64
+		f, err = strconv.ParseFloat(raw, 32)
+		value = float32(f)
+	case bool:
+		value, err = parseBool(raw)
+	case string:
+		value = raw
+	case color.RGBA:
+		value, err = ParseColor(raw)
+	case [2]float32:
+		value, err = parseVec2(raw)
+	case []ModKey:
+		value, err = NormalizeModmask(raw)
+	case GradientValue:
+		value, err = parseGradient(raw, Position{})
+	default:
+		return fmt.Errorf("cannot decode values of type %s", field.Type())
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value %q for a %s: %w", raw, field.Type(), err)
 	}
 
-	prop := ref.FieldByName(field)
-	prop.Set(reflect.ValueOf(value))
+	field.Set(reflect.ValueOf(value))
+	return nil
 }
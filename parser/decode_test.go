@@ -1,24 +1,232 @@
 package parser
 
 import (
+	"errors"
+	"image/color"
 	"testing"
 
-	"github.com/davecgh/go-spew/spew"
+	"github.com/MakeNowJust/heredoc"
+	"go.uber.org/multierr"
 )
 
 func TestHighLevelParse(t *testing.T) {
 	parsed, err := Parse(fixture)
 	if err != nil {
-		t.Errorf("Error while parsing: %s", err)
+		t.Fatalf("Error while parsing: %s", err)
+	}
+
+	config, err := parsed.Decode()
+	for _, err := range multierr.Errors(err) {
+		if !errors.Is(err, ErrUnknownKey) && !errors.Is(err, ErrNoTypedForm) {
+			t.Errorf("unexpected error while decoding: %s", err)
+		}
+	}
+
+	if config.General.GapsOut != 20 || config.General.Layout != "dwindle" {
+		t.Errorf("general section not decoded: %+v", config.General)
+	}
+	if !config.Decoration.Blur.Enabled || config.Decoration.Blur.Size != 10 || config.Decoration.Blur.Passes != 2 {
+		t.Errorf("decoration:blur section not decoded: %+v", config.Decoration.Blur)
+	}
+	if config.Decoration.ActiveOpacity != 0.9 {
+		t.Errorf("decoration:active_opacity not decoded: %v", config.Decoration.ActiveOpacity)
+	}
+	if len(config.General.ColActiveBorder.Stops) != 2 || config.General.ColActiveBorder.Angle != 45 {
+		t.Errorf("general:col.active_border not decoded: %+v", config.General.ColActiveBorder)
+	}
+	if config.CustomVariables["mainMod"] != "SUPER" {
+		t.Errorf("custom variables not decoded: %v", config.CustomVariables)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	parsed, err := Parse(heredoc.Doc(`
+		$gaps = 8
+		$border = rgb(ff0000)
+		general {
+			gaps_in = $gaps
+			col.inactive_border = $border
+			border_size = thick
+			nope = 1
+		}
+		decoration:blur:size = 4
+		decoration {
+			blur:passes = 3
+		}
+		binds:nope = 2
+	`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := parsed.Decode()
+	if config.General.GapsIn != 8 {
+		t.Errorf("expected variables to be expanded, got gaps_in = %d", config.General.GapsIn)
+	}
+	if stops := config.General.ColInactiveBorder.Stops; len(stops) != 1 || stops[0].Color != (color.RGBA{R: 0xff, A: 0xff}) {
+		t.Errorf("unexpected general:col.inactive_border %+v", config.General.ColInactiveBorder)
+	}
+	if config.Decoration.Blur.Size != 4 || config.Decoration.Blur.Passes != 3 {
+		t.Errorf("expected paths in keys to be followed, got %+v", config.Decoration.Blur)
+	}
+
+	errs := multierr.Errors(err)
+	expected := []string{
+		"line 6: general:border_size: invalid value \"thick\" for a int: strconv.Atoi: parsing \"thick\": invalid syntax",
+		"line 7: general:nope: unknown option",
+		"line 13: binds:nope: unknown option",
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got %v", len(expected), errs)
+	}
+	for i, err := range errs {
+		if err.Error() != expected[i] {
+			t.Errorf("unexpected error %q, expected %q", err, expected[i])
+		}
+	}
+}
+
+func TestDecodeInputDevices(t *testing.T) {
+	parsed, err := Parse(heredoc.Doc(`
+		input {
+			touchpad {
+				natural_scroll = true
+			}
+			touchdevice:enabled = false
+			tablet {
+				left_handed = true
+			}
+		}
+	`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := parsed.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !config.Input.Touchpad.NaturalScroll || config.Input.Touchdevice.Enabled || !config.Input.Tablet.LeftHanded {
+		t.Errorf("input devices not decoded: %+v %+v %+v", config.Input.Touchpad, config.Input.Touchdevice, config.Input.Tablet)
+	}
+}
+
+func TestDecodeStatements(t *testing.T) {
+	parsed, err := Parse(heredoc.Doc(`
+		$mod = SUPER
+		env = XCURSOR_SIZE,24
+		monitor = DP-1, 2560x1440@144, 0x0, 1, transform, 1
+		workspace = 1, monitor:DP-1, default:true
+		layerrule = blur, waybar
+		animations {
+			bezier = overshot, 0.05, 0.9, 0.1, 1.05
+			animation = windows, 1, 7, overshot, popin 80%
+			animation = fade, 0
+		}
+		exec-once = waybar & dunst
+		exec = notify-send reloaded
+		bind = $mod, R, submap, resize
+		submap = resize
+		binde = , right, resizeactive, 10 0
+		bind = , escape, submap, reset
+		submap = reset
+	`))
+	if err != nil {
+		t.Fatal(err)
 	}
 
 	config, err := parsed.Decode()
 	if err != nil {
-		t.Errorf("Error while decoding: %s", err)
-		return
-	} else {
-		spew.Dump(config)
+		t.Fatal(err)
+	}
+
+	if len(config.Env) != 1 || config.Env[0].Name != "XCURSOR_SIZE" || config.Env[0].Value != "24" {
+		t.Errorf("unexpected env: %+v", config.Env)
+	}
+	if len(config.Monitors) != 1 || config.Monitors[0].Scale != "1" || len(config.Monitors[0].Options) != 2 {
+		t.Errorf("unexpected monitors: %+v", config.Monitors)
+	}
+	if monitor := config.Monitors[0]; monitor.Statement.Position != (Position{Line: 2, Column: 0}) {
+		t.Errorf("expected the monitor's position to be attached, got %+v", monitor.Statement.Position)
+	}
+	if len(config.WorkspaceRules) != 1 || len(config.WorkspaceRules[0].Rules) != 2 {
+		t.Errorf("unexpected workspace rules: %+v", config.WorkspaceRules)
+	}
+	if len(config.LayerRules) != 1 || config.LayerRules[0].Namespace != "waybar" {
+		t.Errorf("unexpected layer rules: %+v", config.LayerRules)
+	}
+	if len(config.Beziers) != 1 || config.Beziers[0].Points != [4]float32{0.05, 0.9, 0.1, 1.05} {
+		t.Errorf("unexpected beziers: %+v", config.Beziers)
 	}
+	if len(config.AnimationRules) != 2 || config.AnimationRules[0].Style != "popin 80%" || config.AnimationRules[1].Enabled {
+		t.Errorf("unexpected animations: %+v", config.AnimationRules)
+	}
+	if len(config.Execs) != 2 || config.Execs[0].Keyword != "exec-once" || config.Execs[1].Keyword != "" {
+		t.Errorf("unexpected execs: %+v", config.Execs)
+	}
+
+	if len(config.Submaps) != 1 || config.Submaps[0] != "resize" {
+		t.Errorf("unexpected submaps: %v", config.Submaps)
+	}
+	if len(config.Keybinds) != 3 {
+		t.Fatalf("expected 3 keybinds, got %+v", config.Keybinds)
+	}
+	if config.Keybinds[0].Mods != "SUPER" || config.Keybinds[0].Submap != "" {
+		t.Errorf("unexpected global keybind: %+v", config.Keybinds[0])
+	}
+	if config.Keybinds[1].Submap != "resize" || config.Keybinds[2].Submap != "resize" {
+		t.Errorf("expected keybinds to be in the resize submap: %+v", config.Keybinds[1:])
+	}
+
+	expected := heredoc.Doc(`
+		$mod = SUPER
 
-	t.Errorf("TestHighLevelParse not implemented")
+		env = XCURSOR_SIZE,24
+
+		monitor = DP-1, 2560x1440@144, 0x0, 1, transform, 1
+
+		workspace = 1, monitor:DP-1, default:true
+
+		bezier = overshot, 0.05, 0.9, 0.1, 1.05
+
+		animation = windows, 1, 7, overshot, popin 80%
+		animation = fade, 0
+
+		layerrule = blur, waybar
+
+		exec-once = waybar & dunst
+		exec = notify-send reloaded
+
+		bind = SUPER, R, submap, resize
+
+		submap = resize
+		binde = , right, resizeactive, 10 0
+		bind = , escape, submap, reset
+		submap = reset
+	`)
+	if encoded := Encode(config, EncodeOptions{SkipDefaults: true}); encoded != expected {
+		t.Errorf("unexpected encoding:\n%s\nexpected:\n%s", encoded, expected)
+	}
+}
+
+func TestParseKeywordsMissingFromTheWikiSnapshots(t *testing.T) {
+	parsed, err := Parse(heredoc.Doc(`
+		plugin = /usr/lib/hyprexpo.so
+		exec-shutdown = notify-send bye
+		execr = waybar
+		envd = QT_QPA_PLATFORM,wayland
+		bindo = SUPER, Q, killactive
+		binddl = SUPER, M, Mute, exec, pamixer -t
+		permission = /usr/bin/grim, screencopy, allow
+	`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(parsed.Assignments) != 0 {
+		t.Errorf("keywords should not be parsed as options, got %+v", parsed.Assignments)
+	}
+	if len(parsed.Statements) != 7 {
+		t.Errorf("expected 7 statements, got %+v", parsed.Statements)
+	}
 }
@@ -0,0 +1,192 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeKind tells whether something was added, removed or changed between two configurations
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeRemoved ChangeKind = "removed"
+	ChangeChanged ChangeKind = "changed"
+)
+
+// Change is a difference between two configurations
+type Change struct {
+	// Category is what changed: option, variable, bind, monitor, workspace, windowrule, layerrule, bezier, animation, exec or env
+	Category string     `json:"category"`
+	Kind     ChangeKind `json:"kind"`
+	// Key identifies what changed within its category: the full path of an option, the name of a variable, the key combination of a bind, the name of a monitor, etc.
+	Key string `json:"key"`
+	// Old and New are the values before and after the change, as they would be written in a configuration file.
+	// Old is empty for additions and New is empty for removals.
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+}
+
+// String returns a one-line description of the change, e.g. "decoration:blur:size 8 → 6" or "+ bind SUPER + q: exec, kitty"
+func (c Change) String() string {
+	label := c.Category + " " + c.Key + ":"
+	if c.Category == "option" || c.Category == "variable" {
+		label = c.Key
+	}
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s %s", label, quoteIfEmpty(c.New))
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s %s", label, quoteIfEmpty(c.Old))
+	default:
+		return fmt.Sprintf("%s %s → %s", label, quoteIfEmpty(c.Old), quoteIfEmpty(c.New))
+	}
+}
+
+func quoteIfEmpty(value string) string {
+	if value == "" {
+		return `""`
+	}
+	return value
+}
+
+// Diff returns the differences between two configurations.
+// Options are compared by their full path, and keyword statements by what they apply to: binds by key combination and submap, window rules by the windows they match, monitors by name, etc.
+// Statements that apply to the same thing are compared together, so that reordering statements is not a change.
+// Changes are grouped by category, options first. Within a category, changes are in the order of the old configuration, followed by additions in the order of the new one.
+func Diff(old, new Configuration) []Change {
+	changes := diffOptions(reflect.ValueOf(old), reflect.ValueOf(new))
+
+	variables := make([]string, 0, len(old.CustomVariables)+len(new.CustomVariables))
+	for name := range old.CustomVariables {
+		variables = append(variables, name)
+	}
+	for name := range new.CustomVariables {
+		if _, ok := old.CustomVariables[name]; !ok {
+			variables = append(variables, name)
+		}
+	}
+	sort.Strings(variables)
+	for _, name := range variables {
+		before, inOld := old.CustomVariables[name]
+		after, inNew := new.CustomVariables[name]
+		switch {
+		case !inOld:
+			changes = append(changes, Change{Category: "variable", Kind: ChangeAdded, Key: "$" + name, New: after})
+		case !inNew:
+			changes = append(changes, Change{Category: "variable", Kind: ChangeRemoved, Key: "$" + name, Old: before})
+		case before != after:
+			changes = append(changes, Change{Category: "variable", Kind: ChangeChanged, Key: "$" + name, Old: before, New: after})
+		}
+	}
+
+	changes = append(changes, diffStatements("env", old.Env, new.Env, func(env Env) (string, string) {
+		if env.Keyword != "" {
+			return env.Name, env.Value + " (" + env.Keyword + ")"
+		}
+		return env.Name, env.Value
+	})...)
+	changes = append(changes, diffStatements("bind", old.Keybinds, new.Keybinds, func(bind Bind) (string, string) {
+		key := bind.NormalizedCombo()
+		if bind.Submap != "" {
+			key += " in submap " + bind.Submap
+		}
+		return key, bindAction(bind)
+	})...)
+	changes = append(changes, diffStatements("monitor", old.Monitors, new.Monitors, func(monitor Monitor) (string, string) {
+		return monitor.Name, strings.TrimPrefix(encodeMonitor(monitor), encodeLine("monitor", monitor.Name+", "))
+	})...)
+	changes = append(changes, diffStatements("workspace", old.WorkspaceRules, new.WorkspaceRules, func(rule WorkspaceRule) (string, string) {
+		return rule.Workspace, strings.Join(rule.Rules, ", ")
+	})...)
+	changes = append(changes, diffStatements("windowrule", old.WindowRules, new.WindowRules, func(rule WindowRule) (string, string) {
+		if len(rule.Matchers) > 0 {
+			return strings.Join(rule.Matchers, ", "), rule.Rule
+		}
+		return rule.Window, rule.Rule
+	})...)
+	changes = append(changes, diffStatements("layerrule", old.LayerRules, new.LayerRules, func(rule LayerRule) (string, string) {
+		return rule.Namespace, rule.Rule
+	})...)
+	changes = append(changes, diffStatements("bezier", old.Beziers, new.Beziers, func(bezier Bezier) (string, string) {
+		return bezier.Name, strings.TrimPrefix(encodeBezier(bezier), encodeLine("bezier", bezier.Name+", "))
+	})...)
+	changes = append(changes, diffStatements("animation", old.AnimationRules, new.AnimationRules, func(animation Animation) (string, string) {
+		return animation.Name, strings.TrimPrefix(encodeAnimation(animation), encodeLine("animation", animation.Name+", "))
+	})...)
+	changes = append(changes, diffStatements("exec", old.Execs, new.Execs, func(exec Exec) (string, string) {
+		return exec.Command, exec.KeywordOrDefault()
+	})...)
+	return changes
+}
+
+// bindAction returns what a bind does, as written after its key combination, e.g. "exec, kitty (flags: e)"
+func bindAction(bind Bind) string {
+	args := []string{bind.Dispatcher}
+	if bind.Params != "" {
+		args = append(args, bind.Params)
+	}
+	if bind.HasFlag('d') {
+		args = append([]string{bind.Description}, args...)
+	}
+	action := strings.Join(args, ", ")
+	if bind.Flags != "" {
+		action += " (flags: " + bind.Flags + ")"
+	}
+	return action
+}
+
+// diffOptions compares the options of two configurations
+func diffOptions(old, new reflect.Value) []Change {
+	after := make(map[string]string)
+	walkOptions(new, func(path string, field reflect.Value) {
+		after[path] = encodeValue(field)
+	})
+
+	changes := make([]Change, 0)
+	walkOptions(old, func(path string, field reflect.Value) {
+		if before := encodeValue(field); before != after[path] {
+			changes = append(changes, Change{Category: "option", Kind: ChangeChanged, Key: path, Old: before, New: after[path]})
+		}
+	})
+	return changes
+}
+
+// diffStatements compares two lists of statements. describe returns what a statement applies to, and its value.
+// The values of statements that apply to the same thing are compared together, joined with "; ".
+func diffStatements[T any](category string, old, new []T, describe func(T) (key string, value string)) []Change {
+	group := func(statements []T) ([]string, map[string]string) {
+		keys := make([]string, 0)
+		values := make(map[string]string)
+		for _, stmt := range statements {
+			key, value := describe(stmt)
+			if existing, ok := values[key]; ok {
+				values[key] = existing + "; " + value
+			} else {
+				keys = append(keys, key)
+				values[key] = value
+			}
+		}
+		return keys, values
+	}
+	oldKeys, oldValues := group(old)
+	newKeys, newValues := group(new)
+
+	changes := make([]Change, 0)
+	for _, key := range oldKeys {
+		after, ok := newValues[key]
+		if !ok {
+			changes = append(changes, Change{Category: category, Kind: ChangeRemoved, Key: key, Old: oldValues[key]})
+		} else if after != oldValues[key] {
+			changes = append(changes, Change{Category: category, Kind: ChangeChanged, Key: key, Old: oldValues[key], New: after})
+		}
+	}
+	for _, key := range newKeys {
+		if _, ok := oldValues[key]; !ok {
+			changes = append(changes, Change{Category: category, Kind: ChangeAdded, Key: key, New: newValues[key]})
+		}
+	}
+	return changes
+}
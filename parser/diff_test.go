@@ -0,0 +1,68 @@
+package parser
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+)
+
+func TestDiff(t *testing.T) {
+	decode := func(contents string) Configuration {
+		document, err := Parse(contents)
+		if err != nil {
+			t.Fatal(err)
+		}
+		config, err := document.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return config
+	}
+
+	old := decode(heredoc.Doc(`
+		$terminal = kitty
+		decoration:blur:size = 8
+		bind = SUPER, Q, exec, $terminal
+		bind = SUPER, E, exec, dolphin
+		windowrulev2 = float, class:^(pavucontrol)$
+		exec-once = waybar
+	`))
+	new := decode(heredoc.Doc(`
+		$terminal = foot
+		exec-once = waybar
+		windowrulev2 = float, class:^(pavucontrol)$
+		windowrulev2 = size 800 600, class:^(pavucontrol)$
+		bind = super, e, exec, dolphin
+		bind = SUPER, Q, exec, $terminal
+		submap = resize
+		binde = , right, resizeactive, 10 0
+		submap = reset
+		decoration {
+			blur {
+				size = 6
+			}
+		}
+	`))
+
+	expected := []string{
+		"decoration:blur:size 8 → 6",
+		"$terminal kitty → foot",
+		"bind SUPER + q: exec, kitty → exec, foot",
+		"+ bind right in submap resize: resizeactive, 10 0 (flags: e)",
+		"windowrule class:^(pavucontrol)$: float → float; size 800 600",
+	}
+	changes := Diff(old, new)
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %d changes, got %v", len(expected), changes)
+	}
+	for i, change := range changes {
+		if fmt.Sprint(change) != expected[i] {
+			t.Errorf("unexpected change %q, expected %q", change, expected[i])
+		}
+	}
+
+	if changes := Diff(old, old); len(changes) != 0 {
+		t.Errorf("expected no changes between a configuration and itself, got %v", changes)
+	}
+}
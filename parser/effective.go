@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+)
+
+// OptionAssignment is an assignment that set an option while evaluating a configuration
+type OptionAssignment struct {
+	File     string   `json:"file"`
+	Position Position `json:"position"`
+	// Value is the assigned value, with custom variables expanded
+	Value string `json:"value"`
+}
+
+// EffectiveOption is an option of a configuration, with the value it ends up with once the whole configuration is evaluated
+type EffectiveOption struct {
+	// Path is the full path of the option, e.g. decoration:blur:size
+	Path  string `json:"path"`
+	Value string `json:"value"`
+	// Default is the value of the option when no assignment sets it
+	Default   string `json:"default"`
+	IsDefault bool   `json:"isDefault"`
+	// SetBy is the last assignment to the option, which gives it its value. It is nil if the configuration never sets the option.
+	SetBy *OptionAssignment `json:"setBy,omitempty"`
+	// Shadowed are the assignments to the option that came before SetBy, in the order they were evaluated
+	Shadowed []OptionAssignment `json:"shadowed,omitempty"`
+}
+
+// EffectiveOptions evaluates the graph like Decode, and returns every option of the configuration, in the order of the Configuration struct.
+// Since the last assignment to an option wins, the assignments that came before it are returned as shadowed. Assignments with an invalid value are not taken into account, as Hyprland ignores them too.
+func (g *Graph) EffectiveOptions() ([]EffectiveOption, error) {
+	d := g.evaluate()
+
+	defaults := make(map[string]string)
+	walkOptions(reflect.ValueOf(DefaultConfiguration()), func(path string, field reflect.Value) {
+		defaults[path] = encodeValue(field)
+	})
+
+	options := make([]EffectiveOption, 0, len(defaults))
+	walkOptions(reflect.ValueOf(d.config), func(path string, field reflect.Value) {
+		option := EffectiveOption{
+			Path:    path,
+			Value:   encodeValue(field),
+			Default: defaults[path],
+		}
+		option.IsDefault = option.Value == option.Default
+		if assignments := d.assignments[path]; len(assignments) > 0 {
+			option.SetBy = &assignments[len(assignments)-1]
+			option.Shadowed = assignments[:len(assignments)-1]
+		}
+		options = append(options, option)
+	})
+	return options, d.errs
+}
+
+// walkOptions calls f on every option of a configuration, with the option's full path
+func walkOptions(config reflect.Value, f func(path string, field reflect.Value)) {
+	var walk func(section reflect.Value, path []string)
+	walk = func(section reflect.Value, path []string) {
+		for i := 0; i < section.NumField(); i++ {
+			name, _, _ := strings.Cut(section.Type().Field(i).Tag.Get("json"), ",")
+			fieldPath := append(append([]string{}, path...), name)
+			if isSection(section.Field(i)) {
+				walk(section.Field(i), fieldPath)
+			} else if len(path) > 0 {
+				// At the root of a configuration, only sections hold options
+				f(strings.Join(fieldPath, ":"), section.Field(i))
+			}
+		}
+	}
+	walk(config, nil)
+}
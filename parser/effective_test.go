@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+)
+
+func TestEffectiveOptions(t *testing.T) {
+	files := map[string]string{
+		"/hypr/hyprland.conf": heredoc.Doc(`
+			$gaps = 12
+			general {
+				gaps_out = 20
+				border_size = 1
+			}
+			source = ./looks.conf
+			general:gaps_in = nope
+		`),
+		"/hypr/looks.conf": heredoc.Doc(`
+			general:gaps_out = $gaps
+		`),
+	}
+	graph, err := LoadGraph("/hypr/hyprland.conf", func(path string) (string, error) {
+		if contents, ok := files[path]; ok {
+			return contents, nil
+		}
+		return "", fmt.Errorf("open %s: %w", path, os.ErrNotExist)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	options, err := graph.EffectiveOptions()
+	if err == nil {
+		t.Error("expected the invalid value of general:gaps_in to be reported")
+	}
+	byPath := make(map[string]EffectiveOption)
+	for _, option := range options {
+		byPath[option.Path] = option
+	}
+
+	gapsOut := byPath["general:gaps_out"]
+	if gapsOut.Value != "12" || gapsOut.IsDefault || gapsOut.SetBy == nil || gapsOut.SetBy.File != "/hypr/looks.conf" {
+		t.Errorf("expected general:gaps_out to be set by looks.conf, got %+v", gapsOut)
+	}
+	if len(gapsOut.Shadowed) != 1 || gapsOut.Shadowed[0].Value != "20" || gapsOut.Shadowed[0].Position.Line != 2 {
+		t.Errorf("expected general:gaps_out = 20 to be shadowed, got %+v", gapsOut.Shadowed)
+	}
+
+	if borderSize := byPath["general:border_size"]; !borderSize.IsDefault || borderSize.SetBy == nil {
+		t.Errorf("expected general:border_size to be set to its default value, got %+v", borderSize)
+	}
+	if gapsIn := byPath["general:gaps_in"]; gapsIn.SetBy != nil || gapsIn.Value != gapsIn.Default {
+		t.Errorf("expected invalid assignments to be ignored, got %+v", gapsIn)
+	}
+}
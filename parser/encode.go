@@ -0,0 +1,255 @@
+package parser
+
+import (
+	"fmt"
+	"image/color"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EncodeOptions configures Encode
+type EncodeOptions struct {
+	// SkipDefaults omits options that are set to their default value, and sections that only contain such options
+	SkipDefaults bool
+}
+
+// Encode returns the configuration as Hyprlang text, in a canonical form:
+// custom variables come first, sorted by name, followed by environment variables and sections in the order of the Configuration struct.
+// Then come the other keyword statements, grouped by keyword and in the order they were added. Keybinds come last, with the ones of each submap inside a submap = name ... submap = reset block.
+// The output is already formatted, and decoding then encoding it again gives the same text.
+func Encode(config Configuration, options EncodeOptions) string {
+	blocks := make([]string, 0)
+
+	if len(config.CustomVariables) > 0 {
+		names := make([]string, 0, len(config.CustomVariables))
+		for name := range config.CustomVariables {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		lines := make([]string, 0, len(names))
+		for _, name := range names {
+			lines = append(lines, encodeLine("$"+name, config.CustomVariables[name]))
+		}
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+	blocks = appendBlock(blocks, config.Env, encodeEnv)
+
+	defaults := reflect.ValueOf(DefaultConfiguration())
+	value := reflect.ValueOf(config)
+	for i := 0; i < value.NumField(); i++ {
+		if !isSection(value.Field(i)) {
+			continue
+		}
+		name, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("json"), ",")
+		if section := encodeSection(name, value.Field(i), defaults.Field(i), options, ""); section != "" {
+			blocks = append(blocks, section)
+		}
+	}
+
+	blocks = appendBlock(blocks, config.Monitors, encodeMonitor)
+	blocks = appendBlock(blocks, config.WorkspaceRules, encodeWorkspaceRule)
+	blocks = appendBlock(blocks, config.Beziers, encodeBezier)
+	blocks = appendBlock(blocks, config.AnimationRules, encodeAnimation)
+	blocks = appendBlock(blocks, config.WindowRules, encodeWindowRule)
+	blocks = appendBlock(blocks, config.LayerRules, encodeLayerRule)
+	blocks = appendBlock(blocks, config.Execs, encodeExec)
+
+	submaps := append([]string{""}, config.Submaps...)
+	for _, bind := range config.Keybinds {
+		if !slices.Contains(submaps, bind.Submap) {
+			submaps = append(submaps, bind.Submap)
+		}
+	}
+	for _, submap := range submaps {
+		binds := make([]Bind, 0)
+		for _, bind := range config.Keybinds {
+			if bind.Submap == submap {
+				binds = append(binds, bind)
+			}
+		}
+		if submap == "" {
+			blocks = appendBlock(blocks, binds, encodeBind)
+			continue
+		}
+		lines := []string{encodeLine("submap", submap)}
+		for _, bind := range binds {
+			lines = append(lines, encodeBind(bind))
+		}
+		blocks = append(blocks, strings.Join(append(lines, encodeLine("submap", SubmapReset)), "\n"))
+	}
+
+	if len(blocks) == 0 {
+		return ""
+	}
+	return strings.Join(blocks, "\n\n") + "\n"
+}
+
+// appendBlock adds a block with the encoding of every statement of list to blocks, if list is not empty
+func appendBlock[T any](blocks []string, list []T, encode func(T) string) []string {
+	if len(list) == 0 {
+		return blocks
+	}
+	lines := make([]string, 0, len(list))
+	for _, item := range list {
+		lines = append(lines, encode(item))
+	}
+	return append(blocks, strings.Join(lines, "\n"))
+}
+
+// encodeSection returns the section named name, or an empty string if there is nothing to write in it
+func encodeSection(name string, section reflect.Value, defaults reflect.Value, options EncodeOptions, indent string) string {
+	const indentation = "    "
+	lines := make([]string, 0)
+	for i := 0; i < section.NumField(); i++ {
+		key, _, _ := strings.Cut(section.Type().Field(i).Tag.Get("json"), ",")
+		field := section.Field(i)
+		if isSection(field) {
+			if sub := encodeSection(key, field, defaults.Field(i), options, indent+indentation); sub != "" {
+				lines = append(lines, sub)
+			}
+			continue
+		}
+
+		encoded := encodeValue(field)
+		if options.SkipDefaults && encoded == encodeValue(defaults.Field(i)) {
+			continue
+		}
+		lines = append(lines, indent+indentation+encodeLine(key, encoded))
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+	return indent + name + " {\n" + strings.Join(lines, "\n") + "\n" + indent + "}"
+}
+
+// isSection returns true if the field of a Configuration struct is a section, and not an option or a list of statements
+func isSection(field reflect.Value) bool {
+	return field.Kind() == reflect.Struct && field.Type() != reflect.TypeOf(color.RGBA{}) && field.Type() != reflect.TypeOf(GradientValue{})
+}
+
+// encodeLine returns the line that sets key to value, with the # of value escaped so that they don't start a comment
+func encodeLine(key string, value string) string {
+	if value == "" {
+		return key + " ="
+	}
+	return key + " = " + strings.ReplaceAll(value, "#", "##")
+}
+
+// encodeValue returns the value of an option field, as it would be written in a configuration file.
+// It handles every type setValue decodes, which TestEncodeValueOfEveryOption checks.
+func encodeValue(field reflect.Value) string {
+	switch value := field.Interface().(type) {
+	case int:
+		return strconv.Itoa(value)
+	case float32:
+		return encodeFloat(value)
+	case bool:
+		return strconv.FormatBool(value)
+	case string:
+		return value
+	case color.RGBA:
+		return encodeColor(value)
+	case [2]float32:
+		return encodeFloat(value[0]) + " " + encodeFloat(value[1])
+	case []ModKey:
+		mods := make([]string, 0, len(value))
+		for _, mod := range value {
+			mods = append(mods, mod.String())
+		}
+		return strings.Join(mods, " ")
+	case GradientValue:
+		stops := make([]string, 0, len(value.Stops)+1)
+		for _, stop := range value.Stops {
+			stops = append(stops, encodeColor(stop.Color))
+		}
+		if value.Angle != 0 {
+			stops = append(stops, encodeFloat(value.Angle)+"deg")
+		}
+		return strings.Join(stops, " ")
+	default:
+		// setValue cannot decode values of other types, so there is no way to write them that it would read back
+		return fmt.Sprint(value)
+	}
+}
+
+func encodeFloat(f float32) string {
+	return strconv.FormatFloat(float64(f), 'f', -1, 32)
+}
+
+func encodeColor(c color.RGBA) string {
+	return fmt.Sprintf("rgba(%02x%02x%02x%02x)", c.R, c.G, c.B, c.A)
+}
+
+func encodeMonitor(monitor Monitor) string {
+	args := append([]string{monitor.Name, monitor.Resolution, monitor.Position, monitor.Scale}, monitor.Options...)
+	// Don't write empty trailing arguments
+	for len(args) > 2 && args[len(args)-1] == "" {
+		args = args[:len(args)-1]
+	}
+	return encodeLine("monitor", strings.Join(args, ", "))
+}
+
+func encodeWindowRule(rule WindowRule) string {
+	if len(rule.Matchers) > 0 {
+		return encodeLine("windowrulev2", strings.Join(append([]string{rule.Rule}, rule.Matchers...), ", "))
+	}
+	return encodeLine("windowrule", rule.Rule+", "+rule.Window)
+}
+
+func encodeBind(bind Bind) string {
+	args := []string{bind.Mods, bind.Key}
+	if bind.HasFlag('d') {
+		args = append(args, bind.Description)
+	}
+	args = append(args, bind.Dispatcher)
+	if bind.Params != "" {
+		args = append(args, bind.Params)
+	}
+	return encodeLine("bind"+bind.Flags, strings.Join(args, ", "))
+}
+
+func encodeWorkspaceRule(rule WorkspaceRule) string {
+	return encodeLine("workspace", strings.Join(append([]string{rule.Workspace}, rule.Rules...), ", "))
+}
+
+func encodeLayerRule(rule LayerRule) string {
+	return encodeLine("layerrule", rule.Rule+", "+rule.Namespace)
+}
+
+func encodeBezier(bezier Bezier) string {
+	args := []string{bezier.Name}
+	for _, point := range bezier.Points {
+		args = append(args, encodeFloat(point))
+	}
+	return encodeLine("bezier", strings.Join(args, ", "))
+}
+
+func encodeAnimation(animation Animation) string {
+	enabled := "0"
+	if animation.Enabled {
+		enabled = "1"
+	}
+	args := []string{animation.Name, enabled}
+	if animation.Speed != 0 || animation.Curve != "" {
+		args = append(args, encodeFloat(animation.Speed))
+	}
+	if animation.Curve != "" {
+		args = append(args, animation.Curve)
+	}
+	if animation.Style != "" {
+		args = append(args, animation.Style)
+	}
+	return encodeLine("animation", strings.Join(args, ", "))
+}
+
+func encodeExec(exec Exec) string {
+	return encodeLine(exec.KeywordOrDefault(), exec.Command)
+}
+
+func encodeEnv(env Env) string {
+	return encodeLine(env.KeywordOrDefault(), env.Name+","+env.Value)
+}
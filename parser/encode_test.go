@@ -0,0 +1,184 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+)
+
+func TestEncode(t *testing.T) {
+	config := DefaultConfiguration()
+	config.CustomVariables["mainMod"] = "SUPER"
+	config.General.GapsIn = 10
+	config.Decoration.Blur.Size = 3
+	config.Monitors = append(config.Monitors, Monitor{Name: "DP-1", Resolution: "1920x1080@144", Position: "0x0", Scale: "1"})
+	config.WindowRules = append(config.WindowRules, WindowRule{Rule: "float", Matchers: []string{"class:^(pavucontrol)$"}})
+	config.Keybinds = append(config.Keybinds,
+		Bind{Mods: "SUPER", Key: "Q", Dispatcher: "killactive"},
+		Bind{Flags: "e", Mods: "SUPER", Key: "right", Dispatcher: "resizeactive", Params: "10 0"},
+	)
+
+	expected := heredoc.Doc(`
+		$mainMod = SUPER
+
+		general {
+		    gaps_in = 10
+		}
+
+		decoration {
+		    blur {
+		        size = 3
+		    }
+		}
+
+		monitor = DP-1, 1920x1080@144, 0x0, 1
+
+		windowrulev2 = float, class:^(pavucontrol)$
+
+		bind = SUPER, Q, killactive
+		binde = SUPER, right, resizeactive, 10 0
+	`)
+
+	if encoded := Encode(config, EncodeOptions{SkipDefaults: true}); encoded != expected {
+		t.Errorf("unexpected encoding:\n%s\nexpected:\n%s", encoded, expected)
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	for _, options := range []EncodeOptions{{}, {SkipDefaults: true}} {
+		parsed, err := Parse(fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+		config, _ := parsed.Decode()
+		encoded := Encode(config, options)
+
+		reparsed, err := Parse(encoded)
+		if err != nil {
+			t.Fatalf("cannot parse encoded configuration: %s", err)
+		}
+		redecoded, err := reparsed.Decode()
+		if err != nil {
+			t.Fatalf("cannot decode encoded configuration: %s", err)
+		}
+		if again := Encode(redecoded, options); again != encoded {
+			t.Errorf("encoding is not stable with %+v:\n%s\nthen:\n%s", options, encoded, again)
+		}
+		if formatted := Format(encoded, FormatOptions{}); formatted != encoded {
+			t.Errorf("encoded configuration is not formatted with %+v", options)
+		}
+	}
+}
+
+func TestEncodeRoundTripOfHashes(t *testing.T) {
+	config := DefaultConfiguration()
+	config.General.Layout = "master # x"
+	config.CustomVariables["issue"] = "##42"
+	config.Execs = append(config.Execs, Exec{Command: "echo #1"})
+
+	encoded := Encode(config, EncodeOptions{SkipDefaults: true})
+	for _, line := range []string{"$issue = ####42", "layout = master ## x", "exec = echo ##1"} {
+		if !strings.Contains(encoded, line) {
+			t.Errorf("expected %q in the encoding:\n%s", line, encoded)
+		}
+	}
+
+	parsed, err := Parse(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := parsed.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.General.Layout != "master # x" {
+		t.Errorf("expected layout to be %q, got %q", "master # x", decoded.General.Layout)
+	}
+	if decoded.CustomVariables["issue"] != "##42" {
+		t.Errorf("expected $issue to be %q, got %q", "##42", decoded.CustomVariables["issue"])
+	}
+	if len(decoded.Execs) != 1 || decoded.Execs[0].Command != "echo #1" {
+		t.Errorf("expected the exec of echo #1, got %+v", decoded.Execs)
+	}
+}
+
+func TestDecodeEscapedHashes(t *testing.T) {
+	parsed, err := Parse("$issue = issue ##42 # a comment\nexec-once = notify-send ##hyprland\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := parsed.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.CustomVariables["issue"] != "issue #42" {
+		t.Errorf("expected $issue to be %q, got %q", "issue #42", decoded.CustomVariables["issue"])
+	}
+	if len(decoded.Execs) != 1 || decoded.Execs[0].Command != "notify-send #hyprland" {
+		t.Errorf("expected the exec of notify-send #hyprland, got %+v", decoded.Execs)
+	}
+	if encoded := Encode(decoded, EncodeOptions{SkipDefaults: true}); !strings.Contains(encoded, "$issue = issue ##42\n") {
+		t.Errorf("expected the # to be escaped again, got:\n%s", encoded)
+	}
+}
+
+func TestEncodeRoundTripOfExecAndEnvKeywords(t *testing.T) {
+	input := heredoc.Doc(`
+		env = XCURSOR_SIZE,24
+		envd = QT_QPA_PLATFORM,wayland
+
+		exec = notify-send reloaded
+		exec-once = waybar
+		execr = pkill -SIGUSR2 waybar
+		execr-once = dunst
+		exec-shutdown = notify-send bye
+	`)
+
+	parsed, err := Parse(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := parsed.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Execs) != 5 || decoded.Execs[0].Keyword != "" || decoded.Execs[4].Keyword != "exec-shutdown" {
+		t.Errorf("unexpected execs: %+v", decoded.Execs)
+	}
+	if len(decoded.Env) != 2 || decoded.Env[1].Keyword != "envd" || decoded.Env[1].Value != "wayland" {
+		t.Errorf("unexpected env: %+v", decoded.Env)
+	}
+	if encoded := Encode(decoded, EncodeOptions{SkipDefaults: true}); encoded != input {
+		t.Errorf("unexpected encoding:\n%s\nexpected:\n%s", encoded, input)
+	}
+}
+
+func TestEncodeValueOfEveryOption(t *testing.T) {
+	var check func(path string, section reflect.Value)
+	check = func(path string, section reflect.Value) {
+		for i := 0; i < section.NumField(); i++ {
+			field := section.Field(i)
+			name := path + ":" + section.Type().Field(i).Name
+			if isSection(field) {
+				check(name, field)
+				continue
+			}
+
+			decoded := reflect.New(field.Type()).Elem()
+			if err := setValue(decoded, encodeValue(field)); err != nil {
+				t.Errorf("%s: the encoding of a %s cannot be decoded: %s", name, field.Type(), err)
+			} else if encodeValue(decoded) != encodeValue(field) {
+				t.Errorf("%s: %q was decoded to %q", name, encodeValue(field), encodeValue(decoded))
+			}
+		}
+	}
+
+	config := reflect.ValueOf(DefaultConfiguration())
+	for i := 0; i < config.NumField(); i++ {
+		if isSection(config.Field(i)) {
+			check(config.Type().Field(i).Name, config.Field(i))
+		}
+	}
+}
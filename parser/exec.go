@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// execKeywords are the keywords that declare a command to run, with when they run it
+var execKeywords = []Keyword{"exec", "exec-once", "execr", "execr-once", "exec-shutdown"}
+
+// envKeywords are the keywords that declare an environment variable. envd also exports it to the D-Bus activation environment.
+var envKeywords = []Keyword{"env", "envd"}
+
+// Exec is a command declared with one of the exec keywords, such as exec or exec-once.
+// Reference: https://wiki.hyprland.org/Configuring/Keywords/#executing
+type Exec struct {
+	Command string `json:"command"`
+	// Keyword is the keyword the command is declared with, which decides when it runs. It is empty for exec, which runs the command on every reload.
+	Keyword   string    `json:"keyword,omitempty"`
+	Statement Statement `json:"-"`
+}
+
+// IsExec returns true if the statement declares a command to run
+func (s Statement) IsExec() bool {
+	return slices.Contains(execKeywords, s.Keyword)
+}
+
+// ParseExec decodes the arguments of a statement of one of the exec keywords
+func ParseExec(stmt Statement) (Exec, error) {
+	if !stmt.IsExec() {
+		return Exec{}, fmt.Errorf("%s is not an exec statement", stmt.Keyword)
+	}
+	exec := Exec{Command: strings.TrimSpace(stmt.ValueRaw), Statement: stmt}
+	if stmt.Keyword != "exec" {
+		exec.Keyword = string(stmt.Keyword)
+	}
+	return exec, nil
+}
+
+// KeywordOrDefault returns the keyword the command is declared with
+func (exec Exec) KeywordOrDefault() string {
+	if exec.Keyword == "" {
+		return "exec"
+	}
+	return exec.Keyword
+}
+
+// Env is an environment variable declared with the env or envd keyword.
+// Reference: https://wiki.hyprland.org/Configuring/Keywords/#setting-the-environment
+type Env struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	// Keyword is envd for variables that are also exported to D-Bus, and empty for env
+	Keyword   string    `json:"keyword,omitempty"`
+	Statement Statement `json:"-"`
+}
+
+// IsEnv returns true if the statement declares an environment variable
+func (s Statement) IsEnv() bool {
+	return slices.Contains(envKeywords, s.Keyword)
+}
+
+// ParseEnv decodes the arguments of an env or envd statement. The value is kept as-is, even if it contains commas.
+func ParseEnv(stmt Statement) (Env, error) {
+	if !stmt.IsEnv() {
+		return Env{}, fmt.Errorf("%s is not an env statement", stmt.Keyword)
+	}
+
+	name, value, ok := strings.Cut(stmt.ValueRaw, ",")
+	if !ok {
+		return Env{}, fmt.Errorf("%s needs a name and a value", stmt.Keyword)
+	}
+	env := Env{Name: strings.TrimSpace(name), Value: strings.TrimSpace(value), Statement: stmt}
+	if stmt.Keyword != "env" {
+		env.Keyword = string(stmt.Keyword)
+	}
+	return env, nil
+}
+
+// KeywordOrDefault returns the keyword the environment variable is declared with
+func (env Env) KeywordOrDefault() string {
+	if env.Keyword == "" {
+		return "env"
+	}
+	return env.Keyword
+}
@@ -0,0 +1,108 @@
+package parser
+
+import (
+	"strings"
+)
+
+// FormatOptions configures Format
+type FormatOptions struct {
+	// Indent is used once per level of section nesting. Defaults to four spaces.
+	Indent string
+}
+
+// Format returns input formatted in a canonical way, keeping comments:
+//
+//   - lines are indented according to how deeply nested in sections they are
+//   - there is exactly one space around the = of assignments and statements, and before the { that opens a section
+//   - inline comments are separated from the value by one space
+//   - trailing whitespace is removed
+//   - consecutive blank lines are collapsed into one, and blank lines at the start or end of a section are removed
+//
+// Values themselves are left untouched.
+func Format(input string, options FormatOptions) string {
+	if options.Indent == "" {
+		options.Indent = "    "
+	}
+
+	formatted := make([]string, 0)
+	depth := 0
+	blankLinePending := false
+	previousOpensSection := false
+	for _, line := range strings.Split(input, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			blankLinePending = len(formatted) > 0
+			continue
+		}
+
+		// Braces followed by an inline comment still open or close sections
+		code, comment := splitComment(line)
+		code = strings.TrimSpace(code)
+		if comment != "" && code != "" {
+			comment = " " + comment
+		}
+		closesSection := code == "}"
+		opensSection := isSectionStart(code)
+		if closesSection && depth > 0 {
+			depth--
+		}
+
+		if blankLinePending && !closesSection && !previousOpensSection {
+			formatted = append(formatted, "")
+		}
+		blankLinePending = false
+		previousOpensSection = opensSection
+
+		switch {
+		case closesSection:
+			formatted = append(formatted, strings.Repeat(options.Indent, depth)+"}"+comment)
+		case opensSection:
+			formatted = append(formatted, strings.Repeat(options.Indent, depth)+strings.TrimSpace(strings.TrimSuffix(code, "{"))+" {"+comment)
+			depth++
+		default:
+			formatted = append(formatted, strings.Repeat(options.Indent, depth)+formatLine(line))
+		}
+	}
+
+	if len(formatted) == 0 {
+		return ""
+	}
+	return strings.Join(formatted, "\n") + "\n"
+}
+
+// formatLine formats a line that is not the start or end of a section
+func formatLine(line string) string {
+	if strings.HasPrefix(line, "#") {
+		return line
+	}
+
+	code, comment := splitComment(line)
+	code = strings.TrimSpace(code)
+	if key, value, ok := strings.Cut(code, "="); ok {
+		code = strings.TrimSpace(strings.TrimSpace(key) + " =")
+		if value := strings.TrimSpace(value); value != "" {
+			code += " " + value
+		}
+	}
+
+	if comment == "" {
+		return code
+	}
+	return code + " " + comment
+}
+
+// splitComment splits line into its code and its inline comment (including the #).
+// ## is an escaped # and does not start a comment.
+func splitComment(line string) (code string, comment string) {
+	for i := 0; i < len(line); i++ {
+		if line[i] != '#' {
+			continue
+		}
+		if i+1 < len(line) && line[i+1] == '#' {
+			i++
+			continue
+		}
+		return line[:i], line[i:]
+	}
+	return line, ""
+}
@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/ewen-lbh/hyprls/internal/golden"
+	"github.com/ewen-lbh/hyprls/internal/textdiff"
+)
+
+func TestFormat(t *testing.T) {
+	input := heredoc.Doc(`
+
+
+		# Variables
+		$mainMod=SUPER   
+		general{
+
+		  gaps_in=5 # inner gaps
+		     border_size =2
+
+
+		      col.active_border = rgba(33ccffee)##literal
+		  decoration   {
+		  rounding= 10
+		  }
+
+		}
+		bind=$mainMod, Q, exec, kitty
+
+
+	`)
+	expected := heredoc.Doc(`
+		# Variables
+		$mainMod = SUPER
+		general {
+		    gaps_in = 5 # inner gaps
+		    border_size = 2
+
+		    col.active_border = rgba(33ccffee)##literal
+		    decoration {
+		        rounding = 10
+		    }
+		}
+		bind = $mainMod, Q, exec, kitty
+	`)
+
+	formatted := Format(input, FormatOptions{})
+	if formatted != expected {
+		t.Errorf("unexpected formatting:\n%s\nexpected:\n%s", formatted, expected)
+	}
+	if again := Format(formatted, FormatOptions{}); again != formatted {
+		t.Errorf("formatting is not idempotent:\n%s", again)
+	}
+	if tabs := Format("a {\nb=c\n}", FormatOptions{Indent: "\t"}); tabs != "a {\n\tb = c\n}\n" {
+		t.Errorf("unexpected formatting with tabs: %q", tabs)
+	}
+}
+
+func TestFormatLineWithoutKey(t *testing.T) {
+	if formatted := Format("  =  value\n", FormatOptions{}); formatted != "= value\n" {
+		t.Errorf("a line without a key should not start with a space, got %q", formatted)
+	}
+}
+
+func TestFormatSectionsWithComments(t *testing.T) {
+	input := "general { # layout\ngaps_in = 5\ndecoration{#nested\n\nrounding = 3\n} # decoration\n}\n"
+	expected := heredoc.Doc(`
+		general { # layout
+		    gaps_in = 5
+		    decoration { #nested
+		        rounding = 3
+		    } # decoration
+		}
+	`)
+	if formatted := Format(input, FormatOptions{}); formatted != expected {
+		t.Errorf("unexpected formatting:\n%s\nexpected:\n%s", formatted, expected)
+	}
+}
+
+func TestFormatFixtures(t *testing.T) {
+	for _, name := range fixtures(t) {
+		t.Run(name, func(t *testing.T) {
+			contents, err := os.ReadFile(filepath.Join("fixtures", name+".hl"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			formatted := Format(string(contents), FormatOptions{})
+			golden.Assert(t, filepath.Join("fixtures", "golden", name+".formatted.hl"), formatted)
+			if again := Format(formatted, FormatOptions{}); again != formatted {
+				t.Errorf("formatting is not idempotent:\n%s", textdiff.Unified("formatted", "formatted twice", formatted, again))
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// addFixtures adds the configuration files of fixtures/ to the seed corpus of f
+func addFixtures(f *testing.F) {
+	paths, err := filepath.Glob(filepath.Join("fixtures", "*.hl"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, path := range paths {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(string(contents))
+	}
+}
+
+// outline returns what a section means, without the positions of its contents, so that two sections that only differ in whitespace have the same outline
+func outline(section Section) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "%s {\n", section.Name)
+	for _, v := range section.Variables {
+		fmt.Fprintf(&out, "$%s = %q\n", v.Key, v.ValueRaw)
+	}
+	for _, a := range section.Assignments {
+		fmt.Fprintf(&out, "%s = %q\n", a.Key, a.ValueRaw)
+	}
+	for _, s := range section.Statements {
+		fmt.Fprintf(&out, "%s = %q\n", s.Keyword, s.ValueRaw)
+	}
+	for _, sub := range section.Subsections {
+		out.WriteString(outline(sub))
+	}
+	out.WriteString("}\n")
+	return out.String()
+}
+
+func FuzzParse(f *testing.F) {
+	addFixtures(f)
+	f.Add("}\n}\ngeneral {\n")
+	f.Add("a = b = c ## d # e")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		parsed, err := Parse(input)
+		if err != nil {
+			return
+		}
+
+		formatted := Format(input, FormatOptions{})
+		if again := Format(formatted, FormatOptions{}); again != formatted {
+			t.Errorf("formatting is not idempotent: %q then %q", formatted, again)
+		}
+		reparsed, err := Parse(formatted)
+		if err != nil {
+			t.Fatalf("the formatted input could not be parsed: %s", err)
+		}
+		if before, after := outline(parsed), outline(reparsed); before != after {
+			t.Errorf("formatting changed the meaning of the input:\n%s\nbecame\n%s", before, after)
+		}
+	})
+}
+
+func FuzzParseEqualLine(f *testing.F) {
+	f.Add("gaps_in = 5")
+	f.Add("\t$mainMod=SUPER # comment")
+	f.Add("exec = notify-send ##1=2")
+	f.Add("bind = SUPER, Q, exec, kitty")
+
+	f.Fuzz(func(t *testing.T, line string) {
+		ass, _, customVar, isStatement, isCustomVar := ParseEqualLine(strings.TrimSpace(line), line, Position{})
+		if isStatement {
+			return
+		}
+		if isCustomVar {
+			ass = customVar.Assignment
+		}
+		if ass.ValueRaw == "" {
+			return
+		}
+		if start := ass.Value.Start.Column; start > len(line) || !strings.HasPrefix(line[start:], ass.ValueRaw) {
+			t.Errorf("the value %q does not start at column %d of %q", ass.ValueRaw, start, line)
+		}
+	})
+}
+
+func FuzzParseColor(f *testing.F) {
+	f.Add("rgb(ff0000)")
+	f.Add("rgba(33ccffee)")
+	f.Add("0xff00ff00")
+	f.Add("rgba(")
+
+	f.Fuzz(func(t *testing.T, raw string) {
+		color, err := ParseColor(raw)
+		if err != nil {
+			return
+		}
+		printed := fmt.Sprintf("rgba(%02x%02x%02x%02x)", color.R, color.G, color.B, color.A)
+		reparsed, err := ParseColor(printed)
+		if err != nil || reparsed != color {
+			t.Errorf("%q was parsed to %v, printed as %s, and parsed back to %v (error: %v)", raw, color, printed, reparsed, err)
+		}
+		if hex := printed[len("rgba(") : len(printed)-1]; hexToColor(hex) != color {
+			t.Errorf("hexToColor(%q) = %v, expected %v", hex, hexToColor(hex), color)
+		}
+	})
+}
+
+func FuzzHexToColor(f *testing.F) {
+	f.Add("ff0000")
+	f.Add("33ccffee")
+	f.Add("f")
+
+	f.Fuzz(func(t *testing.T, hex string) {
+		hexToColor(hex)
+	})
+}
+
+func FuzzParseGradient(f *testing.F) {
+	f.Add("rgba(33ccffee) rgba(00ff99ee) 45deg")
+	f.Add("rgb(ff0000)")
+	f.Add("  rgb(ff0000)   0xff00ff00 ")
+
+	f.Fuzz(func(t *testing.T, raw string) {
+		gradient, err := parseGradient(raw, Position{})
+		if err != nil {
+			return
+		}
+		stops := make([]string, 0, len(gradient.Stops))
+		for _, stop := range gradient.Stops {
+			stops = append(stops, fmt.Sprintf("rgba(%02x%02x%02x%02x)", stop.Color.R, stop.Color.G, stop.Color.B, stop.Color.A))
+		}
+		printed := strings.Join(stops, " ")
+		if gradient.Angle != 0 {
+			printed += fmt.Sprintf(" %gdeg", gradient.Angle)
+		}
+		reparsed, err := parseGradient(printed, Position{})
+		if err != nil {
+			t.Fatalf("%q was printed as %q, which could not be parsed: %s", raw, printed, err)
+		}
+		if outlineGradient(reparsed) != outlineGradient(gradient) {
+			t.Errorf("%q was parsed to %s, printed as %q, and parsed back to %s", raw, outlineGradient(gradient), printed, outlineGradient(reparsed))
+		}
+	})
+}
+
+// outlineGradient returns the colors and angle of a gradient, without the positions of its stops
+func outlineGradient(gradient GradientValue) string {
+	out := fmt.Sprint(gradient.Angle)
+	for _, stop := range gradient.Stops {
+		out += fmt.Sprint(" ", stop.Color)
+	}
+	return out
+}
@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"strings"
+
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+)
+
+// HeaderPrefix starts the comment that selects the Hyprland version a file targets, as in # hyprlang version v0.41.0
+const HeaderPrefix = "# hyprlang"
+
+// TargetVersion returns the Hyprland version declared in the header of a configuration file, in its canonical form.
+// The header is a comment such as "# hyprlang version v0.41.0", among the comments and blank lines at the top of the file.
+// It returns "" if there is no such header or if the version is invalid.
+func TargetVersion(contents string) string {
+	for _, line := range strings.Split(contents, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "#") {
+			return ""
+		}
+		fields := strings.Fields(strings.TrimPrefix(line, HeaderPrefix))
+		if !strings.HasPrefix(line, HeaderPrefix) || len(fields) != 2 || fields[0] != "version" {
+			continue
+		}
+		version, err := parser_data.ParseVersion(fields[1])
+		if err != nil {
+			return ""
+		}
+		return version
+	}
+	return ""
+}
+
+// TargetVersion returns the Hyprland version the file targets: the one declared in its header, or else in the header of the root of the graph.
+// It returns "" if neither declare one.
+func (g *Graph) TargetVersion(path string) string {
+	if file, ok := g.Files[path]; ok {
+		if version := TargetVersion(file.Contents); version != "" {
+			return version
+		}
+	}
+	if root, ok := g.Files[g.Root]; ok {
+		return TargetVersion(root.Contents)
+	}
+	return ""
+}
@@ -3,7 +3,18 @@ package parser
 import "image/color"
 
 type Configuration struct {
-	CustomVariables map[string]string
+	CustomVariables map[string]string `json:"variables"`
+
+	Keybinds       []Bind          `json:"keybinds"`
+	Submaps        []string        `json:"submaps"`
+	Monitors       []Monitor       `json:"monitors"`
+	WorkspaceRules []WorkspaceRule `json:"workspacerules"`
+	WindowRules    []WindowRule    `json:"windowrules"`
+	LayerRules     []LayerRule     `json:"layerrules"`
+	Beziers        []Bezier        `json:"beziers"`
+	AnimationRules []Animation     `json:"animationrules"`
+	Execs          []Exec          `json:"execs"`
+	Env            []Env           `json:"env"`
 
 	General    ConfigurationGeneral    `json:"general"`
 	Decoration ConfigurationDecoration `json:"decoration"`
@@ -270,6 +281,76 @@ type ConfigurationInput struct {
 
 	// Handles axis events around (gaps/border for tiled, dragarea/border for floated) a focused window. 0 ignores axis events 1 sends out-of-bound coordinates 2 fakes pointer coordinates to the closest point inside the window 3 warps the cursor to the closest point inside the window
 	OffWindowAxisEvents int `json:"off_window_axis_events"`
+
+	Touchpad    ConfigurationInputTouchpad    `json:"touchpad"`
+	Touchdevice ConfigurationInputTouchdevice `json:"touchdevice"`
+	Tablet      ConfigurationInputTablet      `json:"tablet"`
+}
+
+type ConfigurationInputTouchpad struct {
+	// Disable the touchpad while typing.
+	DisableWhileTyping bool `json:"disable_while_typing"`
+
+	// Inverts scrolling direction. When enabled, scrolling moves content directly, rather than manipulating a scrollbar.
+	NaturalScroll bool `json:"natural_scroll"`
+
+	// Multiplier applied to the amount of scroll movement.
+	ScrollFactor float32 `json:"scroll_factor"`
+
+	// Sending LMB and RMB simultaneously will be interpreted as a middle click. This disables any touchpad area that would normally send a middle click based on location. libinput#middle-button-emulation
+	MiddleButtonEmulation bool `json:"middle_button_emulation"`
+
+	// Sets the tap button mapping for touchpad button emulation. Can be one of lrm (default) or lmr (Left, Middle, Right Buttons). [lrm/lmr]
+	TapButtonMap string `json:"tap_button_map"`
+
+	// Button presses with 1, 2, or 3 fingers will be mapped to LMB, RMB, and MMB respectively. This disables interpretation of clicks based on location on the touchpad. libinput#clickfinger-behavior
+	ClickfingerBehavior bool `json:"clickfinger_behavior"`
+
+	// Tapping on the touchpad with 1, 2, or 3 fingers will send LMB, RMB, and MMB respectively.
+	TapToClick bool `json:"tap-to-click"`
+
+	// When enabled, lifting the finger off for a short time while dragging will not drop the dragged item. libinput#tap-and-drag
+	DragLock bool `json:"drag_lock"`
+
+	// Sets the tap and drag mode for the touchpad
+	TapAndDrag bool `json:"tap-and-drag"`
+}
+
+type ConfigurationInputTouchdevice struct {
+	// Transform the input from touchdevices. The possible transformations are the same as those of the monitors
+	Transform int `json:"transform"`
+
+	// The monitor to bind touch devices. The default is auto-detection. To stop auto-detection, use an empty string or the "[[Empty]]" value.
+	Output string `json:"output"`
+
+	// Whether input is enabled for touch devices.
+	Enabled bool `json:"enabled"`
+}
+
+type ConfigurationInputTablet struct {
+	// transform the input from tablets. The possible transformations are the same as those of the monitors
+	Transform int `json:"transform"`
+
+	// the monitor to bind tablets. Empty means unbound.
+	Output string `json:"output"`
+
+	// position of the mapped region in monitor layout.
+	RegionPosition [2]float32 `json:"region_position"`
+
+	// size of the mapped region. When this variable is set, tablet input will be mapped to the region. [0, 0] or invalid size means unset.
+	RegionSize [2]float32 `json:"region_size"`
+
+	// whether the input should be relative
+	RelativeInput bool `json:"relative_input"`
+
+	// if enabled, the tablet will be rotated 180 degrees
+	LeftHanded bool `json:"left_handed"`
+
+	// size of tablet's active area in mm
+	ActiveAreaSize [2]float32 `json:"active_area_size"`
+
+	// position of the active area in mm
+	ActiveAreaPosition [2]float32 `json:"active_area_position"`
 }
 
 type ConfigurationGestures struct {
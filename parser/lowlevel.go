@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	parser_data "github.com/ewen-lbh/hyprls/parser/data"
 	"go.lsp.dev/protocol"
@@ -32,6 +33,8 @@ type Assignment struct {
 	Value    Value    `json:"v"`
 	ValueRaw string   `json:"r"`
 	Position Position `json:"pos"`
+	// End is the position right after the last non-whitespace character of the line
+	End Position `json:"end"`
 }
 
 type Section struct {
@@ -44,6 +47,13 @@ type Section struct {
 	Subsections []Section        `json:"sec"`
 }
 
+func (a Assignment) LSPRange() protocol.Range {
+	return protocol.Range{
+		Start: a.Position.LSP(),
+		End:   a.End.LSP(),
+	}
+}
+
 func (r Section) LSPRange() protocol.Range {
 	return protocol.Range{
 		Start: r.Start.LSP(),
@@ -58,7 +68,26 @@ type CustomVariable struct {
 type Statement struct {
 	Keyword   Keyword  `json:"k"`
 	Arguments []Value  `json:"args"`
+	ValueRaw  string   `json:"r"`
 	Position  Position `json:"pos"`
+	// End is the position right after the last non-whitespace character of the line
+	End Position `json:"end"`
+}
+
+func (s Statement) LSPRange() protocol.Range {
+	return protocol.Range{
+		Start: s.Position.LSP(),
+		End:   s.End.LSP(),
+	}
+}
+
+// RawArguments returns the comma-separated arguments of the statement, as written in the source
+func (s Statement) RawArguments() []string {
+	args := strings.Split(s.ValueRaw, ",")
+	for i, arg := range args {
+		args[i] = strings.TrimSpace(arg)
+	}
+	return args
 }
 
 type Keyword string
@@ -209,6 +238,9 @@ func (v *Value) LSPColor() protocol.Color {
 	}
 }
 
+// Parse reads the sections, assignments, statements and custom variables of a configuration file.
+// It is lenient, since it also runs on files that are being edited: a } that closes no section is ignored,
+// and sections that are still open at the end of the input end on its last line.
 func Parse(input string) (Section, error) {
 	document := Section{
 		Name:        RootSection,
@@ -227,39 +259,48 @@ func Parse(input string) (Section, error) {
 			continue
 		}
 
-		if strings.HasSuffix(line, "{") {
+		// Only the code before an inline comment counts: a # starts a comment even in the middle of a key
+		code, _ := splitComment(line)
+		code = strings.TrimSpace(code)
+
+		if isSectionStart(code) {
 			sectionDepth++
-			section := parseSectionStart(line)
+			section := parseSectionStart(code)
 			section.Start = Position{i, strings.Index(line, "{")}
 			sectionsStack = append(sectionsStack, &section)
 		}
 
-		if strings.Contains(line, "=") {
+		if strings.Contains(code, "=") {
 			ass, stmt, customVar, isStatement, isCustomVar := ParseEqualLine(line, originalLine, Position{i, 0})
-			pos := Position{i, strings.IndexFunc(line, unicode.IsPrint)}
+			pos := Position{i, strings.IndexFunc(originalLine, not(unicode.IsSpace))}
+			end := Position{i, len(strings.TrimRightFunc(originalLine, unicode.IsSpace))}
 			if isCustomVar {
 				customVar.Position = pos
+				customVar.End = end
 				currentSection.Variables = append(currentSection.Variables, customVar)
 			} else if isStatement {
 				stmt.Position = pos
+				stmt.End = end
 				currentSection.Statements = append(currentSection.Statements, stmt)
 			} else {
 				ass.Position = pos
+				ass.End = end
 				currentSection.Assignments = append(currentSection.Assignments, ass)
 			}
 		}
 
-		if line == "}" {
+		if code == "}" && sectionDepth > 0 {
 			currentSection.End = Position{i, strings.Index(originalLine, "}")}
 			sectionsStack[sectionDepth-1].Subsections = append(sectionsStack[sectionDepth-1].Subsections, *sectionsStack[sectionDepth])
 			sectionsStack = sectionsStack[:sectionDepth]
 			sectionDepth--
-			if sectionDepth < 0 {
-				panic("unbalanced section")
-			}
 		}
 		endLine = i
 	}
+	for ; sectionDepth > 0; sectionDepth-- {
+		sectionsStack[sectionDepth].End = Position{endLine, 0}
+		sectionsStack[sectionDepth-1].Subsections = append(sectionsStack[sectionDepth-1].Subsections, *sectionsStack[sectionDepth])
+	}
 	// FIXME 0 is incorrect, but do we care?
 	document.End = Position{endLine, 0}
 	return document, nil
@@ -278,12 +319,13 @@ func ParseEqualLine(line string, originalLine string, start Position) (ass Assig
 	encounteredValue := false
 	valueStart := start
 	valueEnd := Position{start.Line, strings.LastIndexFunc(originalLine, not(unicode.IsSpace))}
+	escaped := false
 	for i, char := range originalLine {
 		if !encounteredEquals && unicode.IsSpace(char) {
 			continue
 		}
 
-		if char == '=' {
+		if char == '=' && !encounteredEquals {
 			encounteredEquals = true
 			continue
 		}
@@ -294,13 +336,23 @@ func ParseEqualLine(line string, originalLine string, start Position) (ass Assig
 		}
 
 		if encounteredValue {
-			if char == '#' {
+			// ## is an escaped #, it is kept as is in the raw value
+			if char == '#' && !escaped && strings.HasPrefix(originalLine[i+1:], "#") {
+				escaped = true
+				valueRaw += string(char)
+				continue
+			}
+			if char == '#' && !escaped {
 				valueEnd.Column = strings.LastIndexFunc(originalLine[:i], not(unicode.IsSpace))
 				break
 			}
-			valueRaw += string(char)
+			escaped = false
+			// Not string(char), which would replace invalid UTF-8 with U+FFFD
+			_, size := utf8.DecodeRuneInString(originalLine[i:])
+			valueRaw += originalLine[i : i+size]
 		}
 	}
+	valueRaw = strings.TrimRightFunc(valueRaw, unicode.IsSpace)
 
 	if isCustomVar {
 		_ass := parseAssignment(strings.TrimPrefix(key, "$"), valueRaw, valueStart)
@@ -328,6 +380,7 @@ func parseStatement(key string, valueRaw string) Statement {
 	return Statement{
 		Keyword:   Keyword(key),
 		Arguments: args,
+		ValueRaw:  valueRaw,
 	}
 }
 
@@ -339,6 +392,11 @@ func parseAssignment(key string, valueRaw string, valueStart Position) Assignmen
 	}
 }
 
+// isSectionStart returns true if code, a line without its comment, opens a section. Lines with a = are assignments, even if they end with a {.
+func isSectionStart(code string) bool {
+	return !strings.Contains(code, "=") && strings.HasSuffix(code, "{")
+}
+
 func parseSectionStart(line string) Section {
 	return Section{
 		Name:        strings.TrimSpace(strings.TrimSuffix(line, "{")),
@@ -486,6 +544,9 @@ func parseGradient(raw string, valueStart Position) (GradientValue, error) {
 				if !GradientAnglePattern.MatchString(arg) {
 					return GradientValue{}, errors.New("invalid gradient angle")
 				}
+				if len(value.Stops) == 0 {
+					return GradientValue{}, errors.New("a gradient needs at least one color")
+				}
 				angle, _ := strconv.ParseFloat(GradientAnglePattern.FindStringSubmatch(arg)[1], 32)
 				value.Angle = float32(angle)
 				return value, nil
@@ -506,10 +567,11 @@ func parseGradient(raw string, valueStart Position) (GradientValue, error) {
 	return value, nil
 }
 
+// hexToColor decodes a color of the form RRGGBB or RRGGBBAA. Missing components are 0, or 0xff for the alpha channel
 func hexToColor(hexstring string) color.RGBA {
 	components := []uint64{0, 0, 0, 0xff}
 	for i := 0; i < 4; i++ {
-		if len(hexstring) <= i*2 {
+		if len(hexstring) >= i*2+2 {
 			components[i], _ = strconv.ParseUint(hexstring[i*2:i*2+2], 16, 8)
 		}
 	}
@@ -2,33 +2,123 @@ package parser
 
 import (
 	_ "embed"
-	"encoding/json"
 	"os"
-	// "strings"
+	"path/filepath"
+	"strings"
 	"testing"
 
-	// "github.com/andreyvit/diff"
+	"github.com/ewen-lbh/hyprls/internal/golden"
 )
 
 //go:embed fixtures/test.hl
 var fixture string
 
-//go:embed fixtures/test.json
-var fixtureResultSnapshot string
+// fixtures returns the names of the configuration files in fixtures/, without their extension.
+// Their expected outputs are in fixtures/golden/, run the tests with -update to rewrite them.
+func fixtures(t *testing.T) []string {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join("fixtures", "*.hl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0, len(paths))
+	for _, path := range paths {
+		names = append(names, strings.TrimSuffix(filepath.Base(path), ".hl"))
+	}
+	return names
+}
 
 func TestLowlevelParse(t *testing.T) {
-	parsed, err := Parse(fixture)
+	for _, name := range fixtures(t) {
+		t.Run(name, func(t *testing.T) {
+			contents, err := os.ReadFile(filepath.Join("fixtures", name+".hl"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			parsed, err := Parse(string(contents))
+			if err != nil {
+				t.Fatalf("Error while parsing: %s", err)
+			}
+			golden.AssertJSON(t, filepath.Join("fixtures", "golden", name+".json"), parsed)
+		})
+	}
+}
+
+func TestParseUnbalancedSections(t *testing.T) {
+	parsed, err := Parse("}\ngeneral {\n    gaps_in = 5\n}\n}\ninput {\n    touchpad {\n        natural_scroll = true")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(parsed.Subsections) != 2 || parsed.Subsections[0].Name != "general" || parsed.Subsections[1].Name != "input" {
+		t.Fatalf("expected the general and input sections, got %+v", parsed.Subsections)
+	}
+	input := parsed.Subsections[1]
+	if len(input.Subsections) != 1 || len(input.Subsections[0].Assignments) != 1 {
+		t.Errorf("expected the touchpad section that is still open to be kept, got %+v", input.Subsections)
+	}
+	if input.End != (Position{7, 0}) || input.Subsections[0].End != (Position{7, 0}) {
+		t.Errorf("sections that are still open should end on the last line, got %+v and %+v", input.End, input.Subsections[0].End)
+	}
+}
+
+func TestParseSectionsWithComments(t *testing.T) {
+	parsed, err := Parse("general { # layout\n    gaps_in = 5\n    decoration { #nested\n        rounding = 3\n    } # decoration\n}\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(parsed.Subsections) != 1 || parsed.Subsections[0].Name != "general" {
+		t.Fatalf("expected the general section, got %+v", parsed.Subsections)
+	}
+	general := parsed.Subsections[0]
+	if len(general.Assignments) != 1 || len(general.Subsections) != 1 || general.Subsections[0].Name != "decoration" {
+		t.Fatalf("expected gaps_in and the decoration section in general, got %+v", general)
+	}
+	if general.End != (Position{5, 0}) || general.Subsections[0].End != (Position{4, 4}) {
+		t.Errorf("unexpected ends %+v and %+v", general.End, general.Subsections[0].End)
+	}
+}
+
+func TestParseCommentsInKeys(t *testing.T) {
+	parsed, err := Parse("gaps#in = 5\nrounding = 3 {\n}\n")
 	if err != nil {
-		t.Errorf("Error while parsing: %s", err)
-	}
-
-	contents, _ := json.MarshalIndent(parsed, "", "  ")
-	os.WriteFile("fixtures/test.json", contents, 0644)
-	// if strings.TrimSpace(fixtureResultSnapshot) == "update" {
-	// 	os.WriteFile("fixtures/test.json", contents, 0644)
-	// } else {
-	// 	if string(contents) != fixtureResultSnapshot {
-	// 		t.Errorf("Parsed result does not match snapshot:\n%v", diff.LineDiff(string(contents), fixtureResultSnapshot))
-	// 	}
-	// }
+		t.Fatal(err)
+	}
+
+	if len(parsed.Assignments) != 1 || parsed.Assignments[0].Key != "rounding" {
+		t.Errorf("expected only the assignment to rounding, got %+v", parsed.Assignments)
+	}
+	if len(parsed.Subsections) != 0 {
+		t.Errorf("a line with a = should not open a section, got %+v", parsed.Subsections)
+	}
+}
+
+func TestParseEqualLineKeepsEqualSignsInValues(t *testing.T) {
+	line := "exec = foo --bar=baz --qux=1"
+	_, stmt, _, isStatement, _ := ParseEqualLine(line, line, Position{})
+	if !isStatement || stmt.ValueRaw != "foo --bar=baz --qux=1" {
+		t.Errorf("unexpected value %q", stmt.ValueRaw)
+	}
+
+	line = "  $cmd = a=b"
+	_, _, customVar, _, isCustomVar := ParseEqualLine(strings.TrimSpace(line), line, Position{})
+	if !isCustomVar || customVar.Key != "cmd" || customVar.ValueRaw != "a=b" {
+		t.Errorf("unexpected custom variable %+v", customVar.Assignment)
+	}
+}
+
+func TestParseEqualLineEscapedComments(t *testing.T) {
+	for line, expected := range map[string]string{
+		`exec-once = notify-send "Welcome ##1"   # a comment`: `notify-send "Welcome ##1"`,
+		"exec = a ####b":    "a ####b",
+		"exec = a ###b":     "a ##",
+		"exec = a # b ## c": "a",
+	} {
+		_, stmt, _, _, _ := ParseEqualLine(line, line, Position{})
+		if stmt.ValueRaw != expected {
+			t.Errorf("%q: expected the value %q, got %q", line, expected, stmt.ValueRaw)
+		}
+	}
 }
@@ -0,0 +1,197 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.uber.org/multierr"
+)
+
+// ToMap returns the configuration as nested maps, keyed by the JSON names of the fields of Configuration, ready to be written as JSON, YAML or TOML.
+// Integers, floats, booleans and strings stay as-is, vec2 options become lists of two numbers, and other options (colors, gradients and modmasks) are written as they would be in a configuration file.
+// Keyword statements are lists of maps, keyed by the JSON names of their fields.
+func (config Configuration) ToMap(options EncodeOptions) (map[string]any, error) {
+	data := make(map[string]any)
+	defaults := reflect.ValueOf(DefaultConfiguration())
+	value := reflect.ValueOf(config)
+	for i := 0; i < value.NumField(); i++ {
+		name, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("json"), ",")
+		field := value.Field(i)
+		switch {
+		case isSection(field):
+			if section := sectionToMap(field, defaults.Field(i), options); len(section) > 0 {
+				data[name] = section
+			}
+		case field.Len() > 0:
+			// Keyword statements and custom variables are converted through their JSON representation
+			encoded, err := json.Marshal(field.Interface())
+			if err != nil {
+				return nil, fmt.Errorf("while converting %s: %w", name, err)
+			}
+			var converted any
+			if err := json.Unmarshal(encoded, &converted); err != nil {
+				return nil, fmt.Errorf("while converting %s: %w", name, err)
+			}
+			data[name] = converted
+		}
+	}
+	return data, nil
+}
+
+func sectionToMap(section reflect.Value, defaults reflect.Value, options EncodeOptions) map[string]any {
+	data := make(map[string]any)
+	for i := 0; i < section.NumField(); i++ {
+		key, _, _ := strings.Cut(section.Type().Field(i).Tag.Get("json"), ",")
+		field := section.Field(i)
+		if isSection(field) {
+			if sub := sectionToMap(field, defaults.Field(i), options); len(sub) > 0 {
+				data[key] = sub
+			}
+			continue
+		}
+		if options.SkipDefaults && encodeValue(field) == encodeValue(defaults.Field(i)) {
+			continue
+		}
+
+		switch value := field.Interface().(type) {
+		case int, bool, string:
+			data[key] = value
+		case float32:
+			// Go through the shortest decimal representation, so that 0.9 doesn't become 0.8999999761581421
+			data[key], _ = strconv.ParseFloat(encodeFloat(value), 64)
+		case [2]float32:
+			x, _ := strconv.ParseFloat(encodeFloat(value[0]), 64)
+			y, _ := strconv.ParseFloat(encodeFloat(value[1]), 64)
+			data[key] = []any{x, y}
+		default:
+			data[key] = encodeValue(field)
+		}
+	}
+	return data
+}
+
+// FromMap returns the configuration described by data, which has the same shape as what ToMap returns.
+// Options that data does not set keep their default value.
+// All the keys and values that cannot be decoded are reported, combined with multierr, along with the configuration decoded from the rest of data.
+func FromMap(data map[string]any) (Configuration, error) {
+	config := DefaultConfiguration()
+	value := reflect.ValueOf(&config).Elem()
+
+	var errs error
+	for _, name := range sortedKeys(data) {
+		field, ok := fieldByJSONName(value, name, true)
+		if !ok {
+			errs = multierr.Append(errs, fmt.Errorf("%s: %w", name, ErrUnknownKey))
+			continue
+		}
+		if isSection(field) {
+			errs = multierr.Append(errs, sectionFromMap(field, data[name], []string{name}))
+			continue
+		}
+
+		// Keyword statements and custom variables are converted through their JSON representation
+		encoded, err := json.Marshal(data[name])
+		if err == nil {
+			decoder := json.NewDecoder(bytes.NewReader(encoded))
+			decoder.DisallowUnknownFields()
+			err = decoder.Decode(field.Addr().Interface())
+		}
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return config, errs
+}
+
+func sectionFromMap(section reflect.Value, raw any, path []string) error {
+	data, ok := raw.(map[string]any)
+	if !ok {
+		return fmt.Errorf("%s: expected a section, got %s", strings.Join(path, ":"), describeType(raw))
+	}
+
+	var errs error
+	for _, key := range sortedKeys(data) {
+		keyPath := append(append([]string{}, path...), key)
+		field, ok := fieldByJSONName(section, key, false)
+		if !ok {
+			errs = multierr.Append(errs, fmt.Errorf("%s: %w", strings.Join(keyPath, ":"), ErrUnknownKey))
+			continue
+		}
+		if isSection(field) {
+			errs = multierr.Append(errs, sectionFromMap(field, data[key], keyPath))
+			continue
+		}
+
+		raw, err := optionValueFromMap(field, data[key])
+		if err == nil {
+			err = setValue(field, raw)
+		}
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("%s: %w", strings.Join(keyPath, ":"), err))
+		}
+	}
+	return errs
+}
+
+// optionValueFromMap returns the value of an option as it would be written in a configuration file, from its representation in a map returned by ToMap
+func optionValueFromMap(field reflect.Value, value any) (string, error) {
+	if _, isVec2 := field.Interface().([2]float32); isVec2 {
+		list, ok := value.([]any)
+		if !ok || len(list) != 2 {
+			return "", fmt.Errorf("expected a list of two numbers, got %s", describeType(value))
+		}
+		x, errX := optionValueFromMap(reflect.ValueOf(float32(0)), list[0])
+		y, errY := optionValueFromMap(reflect.ValueOf(float32(0)), list[1])
+		if err := multierr.Append(errX, errY); err != nil {
+			return "", err
+		}
+		return x + " " + y, nil
+	}
+
+	switch value := value.(type) {
+	case string:
+		return value, nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	case int:
+		return strconv.Itoa(value), nil
+	case int64:
+		return strconv.FormatInt(value, 10), nil
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("expected a %s, got %s", field.Type(), describeType(value))
+	}
+}
+
+// describeType returns a human-readable name for the type of a value found in JSON, YAML or TOML data
+func describeType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "nothing"
+	case map[string]any:
+		return "a section"
+	case []any:
+		return "a list"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	default:
+		return "a number"
+	}
+}
+
+func sortedKeys(data map[string]any) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
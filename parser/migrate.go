@@ -0,0 +1,193 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"go.lsp.dev/protocol"
+)
+
+// Deprecation is an assignment to an option that was renamed or moved, or a statement of a legacy keyword, along with the edits that migrate it
+type Deprecation struct {
+	// Path is the full path of the deprecated option, or the deprecated keyword
+	Path string
+	// Replacement is the full path of the option, or the keyword, that replaces it
+	Replacement string
+	// In is the Hyprland version that deprecated it, or "" if it is deprecated in every known version
+	In string
+	// Range is the range of the assignment or statement
+	Range protocol.Range
+	// Edits migrate the assignment or statement, keeping its trailing comment. They are empty if it must be migrated by hand, see Manual.
+	Edits []protocol.TextEdit
+	// Manual is true if the value of the assignment uses variables and has to be converted to a value of the new option, which cannot be done without evaluating the whole configuration
+	Manual bool
+}
+
+// Message describes the deprecation, e.g. "decoration:drop_shadow was replaced by decoration:shadow:enabled in v0.45.0"
+func (d Deprecation) Message() string {
+	message := fmt.Sprintf("%s was replaced by %s in %s", d.Path, d.Replacement, d.In)
+	if d.In == "" {
+		message = fmt.Sprintf("%s is deprecated, use %s instead", d.Path, d.Replacement)
+	}
+	if d.Manual {
+		message += ". Its value uses variables, so it must be migrated by hand"
+	}
+	return message
+}
+
+// Title describes the edits, e.g. "Replace decoration:drop_shadow with decoration:shadow:enabled"
+func (d Deprecation) Title() string {
+	return fmt.Sprintf("Replace %s with %s", d.Path, d.Replacement)
+}
+
+// Deprecations returns the deprecated options and statements of the file that should be migrated in configurations that target version, in the order they appear in the file.
+// An empty version stands for the latest one.
+func (f *ConfigFile) Deprecations(version string) []Deprecation {
+	lines := strings.Split(f.Contents, "\n")
+	deprecations := make([]Deprecation, 0)
+	for _, entry := range f.Entries() {
+		switch {
+		case entry.Assignment != nil:
+			path := OptionPath(entry.SectionPath, entry.Assignment.Key)
+			migration, ok := parser_data.FindOptionMigration(strings.Join(path, parser_data.PathSeparator))
+			if !ok || !migration.AppliesTo(version) {
+				continue
+			}
+			deprecation := Deprecation{
+				Path:        migration.From,
+				Replacement: migration.To,
+				In:          migration.In,
+				Range:       entry.Assignment.LSPRange(),
+				Manual:      !migration.CanMigrate(entry.Assignment.ValueRaw),
+			}
+			if !deprecation.Manual {
+				deprecation.Edits = f.migrateAssignment(lines, entry, migration)
+			}
+			deprecations = append(deprecations, deprecation)
+		case entry.Statement != nil:
+			stmt := entry.Statement
+			migration, ok := parser_data.FindKeywordMigration(string(stmt.Keyword))
+			if !ok || !migration.AppliesTo(version) {
+				continue
+			}
+			deprecations = append(deprecations, Deprecation{
+				Path:        migration.From,
+				Replacement: migration.To,
+				In:          migration.In,
+				Range:       stmt.LSPRange(),
+				Edits: []protocol.TextEdit{{
+					Range:   stmt.LSPRange(),
+					NewText: migration.To + " = " + migration.Migrate(strings.TrimSpace(stmt.ValueRaw)) + trailingComment(lines[stmt.Position.Line]),
+				}},
+			})
+		}
+	}
+	return deprecations
+}
+
+// migrateAssignment returns the edits that replace the assignment with one to the new option.
+// The assignment is rewritten in place if the new option is in the same section, or moved right after the top-level section it is in otherwise.
+func (f *ConfigFile) migrateAssignment(lines []string, entry Entry, migration parser_data.OptionMigration) []protocol.TextEdit {
+	assignment := entry.Assignment
+	comment := trailingComment(lines[assignment.Position.Line])
+	value := migration.Migrate(strings.TrimSpace(assignment.ValueRaw))
+
+	newPath := strings.Split(migration.To, parser_data.PathSeparator)
+	if isPathPrefix(entry.SectionPath, newPath) {
+		key := strings.Join(newPath[len(entry.SectionPath):], parser_data.PathSeparator)
+		return []protocol.TextEdit{{Range: assignment.LSPRange(), NewText: key + " = " + value + comment}}
+	}
+
+	line := assignment.Position.Line
+	remove := protocol.Range{
+		Start: protocol.Position{Line: uint32(line)},
+		End:   protocol.Position{Line: uint32(line + 1)},
+	}
+	if line+1 >= len(lines) {
+		remove.End = protocol.Position{Line: uint32(line), Character: uint32(len(lines[line]))}
+	}
+
+	var outer Section
+	for _, section := range f.Document.Subsections {
+		if section.Start.Line <= line && line <= section.End.Line {
+			outer = section
+		}
+	}
+	indentation := lines[outer.Start.Line][:len(lines[outer.Start.Line])-len(strings.TrimLeft(lines[outer.Start.Line], " \t"))]
+	text := indentation + migration.To + " = " + value + comment + "\n"
+	insert := protocol.Position{Line: uint32(outer.End.Line + 1)}
+	if outer.End.Line+1 >= len(lines) {
+		insert = protocol.Position{Line: uint32(outer.End.Line), Character: uint32(len(lines[outer.End.Line]))}
+		text = "\n" + strings.TrimSuffix(text, "\n")
+	}
+	return []protocol.TextEdit{
+		{Range: remove, NewText: ""},
+		{Range: protocol.Range{Start: insert, End: insert}, NewText: text},
+	}
+}
+
+// isPathPrefix returns true if the section path prefix is the start of path, and path has at least one more element. Names are compared case-insensitively.
+func isPathPrefix(prefix, path []string) bool {
+	if len(prefix) >= len(path) {
+		return false
+	}
+	for i := range prefix {
+		if !strings.EqualFold(prefix[i], path[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// trailingComment returns the inline comment of a line, preceded by a space, or "" if it has none
+func trailingComment(line string) string {
+	if _, comment := splitComment(line); comment != "" {
+		return " " + comment
+	}
+	return ""
+}
+
+// Migrate returns the contents of the file with every deprecation that should be migrated in configurations that target version migrated, see Deprecations
+func (f *ConfigFile) Migrate(version string) string {
+	edits := make([]protocol.TextEdit, 0)
+	for _, deprecation := range f.Deprecations(version) {
+		edits = append(edits, deprecation.Edits...)
+	}
+	return ApplyEdits(f.Contents, edits)
+}
+
+// ApplyEdits returns contents with the edits applied. Edits must not overlap, and edits that insert text at the same position insert it in the order they are given.
+func ApplyEdits(contents string, edits []protocol.TextEdit) string {
+	lineStarts := []int{0}
+	for i, char := range contents {
+		if char == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+	offset := func(position protocol.Position) int {
+		if int(position.Line) >= len(lineStarts) {
+			return len(contents)
+		}
+		return min(lineStarts[position.Line]+int(position.Character), len(contents))
+	}
+
+	// Apply edits from the end, so that the offsets of the ones that are left stay valid
+	order := make([]int, len(edits))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := offset(edits[order[i]].Range.Start), offset(edits[order[j]].Range.Start)
+		if a != b {
+			return a > b
+		}
+		return order[i] > order[j]
+	})
+	for _, i := range order {
+		start, end := offset(edits[i].Range.Start), offset(edits[i].Range.End)
+		contents = contents[:start] + edits[i].NewText + contents[end:]
+	}
+	return contents
+}
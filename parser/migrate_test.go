@@ -0,0 +1,108 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+)
+
+func TestMigrate(t *testing.T) {
+	contents := heredoc.Doc(`
+		# hyprlang version v0.40.0
+		general {
+			gaps_in = 5
+			no_cursor_warps = true # keeps the cursor still
+		}
+
+		decoration {
+			rounding = 4
+			drop_shadow = false
+		}
+		master:new_is_master = true
+		windowrule = float, ^(pavucontrol)$ # mixer
+		windowrule = opacity 0.9, title:^(Firefox)$
+		misc {
+			no_direct_scanout = true
+		}`)
+	document, err := Parse(contents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := &ConfigFile{Path: "/hypr/hyprland.conf", Contents: contents, Document: document}
+
+	if deprecations := file.Deprecations("v0.41.0"); len(deprecations) != 2 {
+		t.Errorf("expected only the migrations of v0.41.0 to apply, got %+v", deprecations)
+	}
+
+	expected := heredoc.Doc(`
+		# hyprlang version v0.40.0
+		general {
+			gaps_in = 5
+		}
+		cursor:no_warps = true # keeps the cursor still
+
+		decoration {
+			rounding = 4
+			shadow:enabled = false
+		}
+		master:new_status = master
+		windowrulev2 = float, class:^(pavucontrol)$ # mixer
+		windowrulev2 = opacity 0.9, title:^(Firefox)$
+		misc {
+		}
+		render:direct_scanout = false`)
+	if migrated := file.Migrate(""); migrated != expected {
+		t.Errorf("unexpected migration:\n%s\nexpected:\n%s", migrated, expected)
+	}
+}
+
+func TestMigrateWindowRules(t *testing.T) {
+	tests := map[string]string{
+		"windowrule = float, ^(kitty)$":                     "windowrulev2 = float, class:^(kitty)$",
+		"windowrule = float, class:kitty":                   "windowrulev2 = float, class:kitty",
+		"windowrule = float, initialClass:foo":              "windowrulev2 = float, initialClass:foo",
+		"windowrule = float, initialclass:foo":              "windowrulev2 = float, initialclass:foo",
+		"windowrule = move 0 0, title:^(Firefox)(.*)$":      "windowrulev2 = move 0 0, title:^(Firefox)(.*)$",
+		"windowrule = float, xwayland:1":                    "windowrulev2 = float, xwayland:1",
+		"windowrule = opacity 0.5, floating:1, class:kitty": "windowrulev2 = opacity 0.5, floating:1, class:kitty",
+	}
+
+	for input, expected := range tests {
+		document, err := Parse(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file := &ConfigFile{Path: "/hypr/hyprland.conf", Contents: input, Document: document}
+		if migrated := file.Migrate(""); migrated != expected {
+			t.Errorf("%q migrated to %q, expected %q", input, migrated, expected)
+		}
+	}
+}
+
+func TestMigrateValuesWithVariables(t *testing.T) {
+	contents := heredoc.Doc(`
+		$master = true
+		master:new_is_master = $master
+		decoration:drop_shadow = $master`)
+	document, err := Parse(contents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := &ConfigFile{Path: "/hypr/hyprland.conf", Contents: contents, Document: document}
+
+	deprecations := file.Deprecations("")
+	if len(deprecations) != 2 || !deprecations[0].Manual || len(deprecations[0].Edits) != 0 || deprecations[1].Manual {
+		t.Fatalf("expected only the value that needs to be converted to be migrated by hand, got %+v", deprecations)
+	}
+	if message := deprecations[0].Message(); message != "master:new_is_master was replaced by master:new_status in v0.41.0. Its value uses variables, so it must be migrated by hand" {
+		t.Errorf("unexpected message %q", message)
+	}
+
+	expected := heredoc.Doc(`
+		$master = true
+		master:new_is_master = $master
+		decoration:shadow:enabled = $master`)
+	if migrated := file.Migrate(""); migrated != expected {
+		t.Errorf("unexpected migration:\n%s\nexpected:\n%s", migrated, expected)
+	}
+}
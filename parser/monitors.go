@@ -0,0 +1,41 @@
+package parser
+
+import "fmt"
+
+// Monitor is a monitor declared with the monitor keyword.
+// Reference: https://wiki.hyprland.org/Configuring/Monitors/
+type Monitor struct {
+	// Name is the name or description of the monitor. It is empty for rules that apply to any monitor.
+	Name string `json:"name"`
+	// Resolution is e.g. 1920x1080@144, preferred or disable
+	Resolution string `json:"resolution"`
+	Position   string `json:"position,omitempty"`
+	Scale      string `json:"scale,omitempty"`
+	// Options are the extra arguments, e.g. ["transform", "1"]
+	Options   []string  `json:"options,omitempty"`
+	Statement Statement `json:"-"`
+}
+
+// ParseMonitor decodes the arguments of a monitor statement
+func ParseMonitor(stmt Statement) (Monitor, error) {
+	if stmt.Keyword != "monitor" {
+		return Monitor{}, fmt.Errorf("%s is not a monitor statement", stmt.Keyword)
+	}
+
+	args := stmt.RawArguments()
+	if len(args) < 2 {
+		return Monitor{}, fmt.Errorf("monitor needs at least 2 arguments, got %d", len(args))
+	}
+
+	monitor := Monitor{Name: args[0], Resolution: args[1], Statement: stmt}
+	if len(args) > 2 {
+		monitor.Position = args[2]
+	}
+	if len(args) > 3 {
+		monitor.Scale = args[3]
+	}
+	if len(args) > 4 {
+		monitor.Options = args[4:]
+	}
+	return monitor, nil
+}
@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WindowRule is a rule declared with the windowrule or windowrulev2 keyword.
+// Reference: https://wiki.hyprland.org/Configuring/Window-Rules/
+type WindowRule struct {
+	// Rule is the rule to apply, with its arguments, e.g. "opacity 0.8"
+	Rule string `json:"rule"`
+	// Window is the regular expression windows must match, for rules declared with windowrule
+	Window string `json:"window,omitempty"`
+	// Matchers are the property:regex pairs windows must match, for rules declared with windowrulev2
+	Matchers  []string  `json:"matchers,omitempty"`
+	Statement Statement `json:"-"`
+}
+
+// ParseWindowRule decodes the arguments of a windowrule or windowrulev2 statement
+func ParseWindowRule(stmt Statement) (WindowRule, error) {
+	if stmt.Keyword != "windowrule" && stmt.Keyword != "windowrulev2" {
+		return WindowRule{}, fmt.Errorf("%s is not a window rule statement", stmt.Keyword)
+	}
+
+	args := stmt.RawArguments()
+	if len(args) < 2 {
+		return WindowRule{}, fmt.Errorf("%s needs at least 2 arguments, got %d", stmt.Keyword, len(args))
+	}
+
+	rule := WindowRule{Rule: args[0], Statement: stmt}
+	if stmt.Keyword == "windowrule" {
+		rule.Window = strings.Join(args[1:], ", ")
+	} else {
+		rule.Matchers = args[1:]
+	}
+	return rule, nil
+}
+
+// LayerRule is a rule declared with the layerrule keyword.
+// Reference: https://wiki.hyprland.org/Configuring/Window-Rules/#layer-rules
+type LayerRule struct {
+	Rule string `json:"rule"`
+	// Namespace is the regular expression layers' namespaces must match, or the address of a layer
+	Namespace string    `json:"namespace"`
+	Statement Statement `json:"-"`
+}
+
+// ParseLayerRule decodes the arguments of a layerrule statement
+func ParseLayerRule(stmt Statement) (LayerRule, error) {
+	if stmt.Keyword != "layerrule" {
+		return LayerRule{}, fmt.Errorf("%s is not a layerrule statement", stmt.Keyword)
+	}
+
+	args := stmt.RawArguments()
+	if len(args) < 2 {
+		return LayerRule{}, fmt.Errorf("layerrule needs 2 arguments, got %d", len(args))
+	}
+	return LayerRule{Rule: args[0], Namespace: strings.Join(args[1:], ", "), Statement: stmt}, nil
+}
+
+// WorkspaceRule is a rule declared with the workspace keyword.
+// Reference: https://wiki.hyprland.org/Configuring/Workspace-Rules/
+type WorkspaceRule struct {
+	// Workspace identifies the workspace, e.g. 1, name:coding or special:scratchpad
+	Workspace string `json:"workspace"`
+	// Rules are the rule:value pairs, e.g. ["monitor:DP-1", "default:true"]
+	Rules     []string  `json:"rules,omitempty"`
+	Statement Statement `json:"-"`
+}
+
+// ParseWorkspaceRule decodes the arguments of a workspace statement
+func ParseWorkspaceRule(stmt Statement) (WorkspaceRule, error) {
+	if stmt.Keyword != "workspace" {
+		return WorkspaceRule{}, fmt.Errorf("%s is not a workspace statement", stmt.Keyword)
+	}
+
+	args := stmt.RawArguments()
+	if args[0] == "" {
+		return WorkspaceRule{}, fmt.Errorf("workspace needs a workspace identifier")
+	}
+	rule := WorkspaceRule{Workspace: args[0], Statement: stmt}
+	if len(args) > 1 {
+		rule.Rules = args[1:]
+	}
+	return rule, nil
+}
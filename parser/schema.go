@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+)
+
+// statementsDescriptions describe the lists of statements of a Configuration, by JSON name
+var statementsDescriptions = map[string]string{
+	"keybinds":       "keybinds declared with bind and its variants, such as binde",
+	"submaps":        "names of the submaps declared with submap = name",
+	"monitors":       "monitors configured with the monitor keyword",
+	"workspacerules": "workspace rules declared with the workspace keyword",
+	"windowrules":    "window rules declared with windowrule or windowrulev2",
+	"layerrules":     "layer rules declared with layerrule",
+	"beziers":        "bezier curves declared with bezier",
+	"animationrules": "animations configured with animation",
+	"execs":          "commands run with exec, exec-once, execr, execr-once or exec-shutdown",
+	"env":            "environment variables declared with env or envd",
+}
+
+// JSONSchema returns a JSON Schema (draft 2020-12) of the JSON representation of a Configuration, as produced by Decode.
+// The options come from parser_data.JSONSchema, and the lists of statements are described from the types of the fields of Configuration.
+func JSONSchema() map[string]any {
+	schema := parser_data.JSONSchema()
+	properties := schema["properties"].(map[string]any)
+
+	config := reflect.TypeOf(Configuration{})
+	for i := 0; i < config.NumField(); i++ {
+		field := config.Field(i)
+		if field.Type.Kind() != reflect.Slice {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		statements := typeSchema(field.Type)
+		statements["description"] = statementsDescriptions[name]
+		properties[name] = statements
+	}
+	return schema
+}
+
+// typeSchema returns the JSON Schema of the JSON representation of values of type t
+func typeSchema(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Array:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem()), "minItems": t.Len(), "maxItems": t.Len()}
+	case reflect.Struct:
+		properties := make(map[string]any)
+		for i := 0; i < t.NumField(); i++ {
+			name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+			if name == "-" || !t.Field(i).IsExported() {
+				continue
+			}
+			if name == "" {
+				name = t.Field(i).Name
+			}
+			properties[name] = typeSchema(t.Field(i).Type)
+		}
+		return map[string]any{"type": "object", "properties": properties, "additionalProperties": false}
+	default:
+		return map[string]any{"type": "string"}
+	}
+}
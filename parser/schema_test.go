@@ -0,0 +1,41 @@
+package parser
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJSONSchema(t *testing.T) {
+	properties := JSONSchema()["properties"].(map[string]any)
+
+	// Everything Decode can output is described
+	config := reflect.TypeOf(Configuration{})
+	for i := 0; i < config.NumField(); i++ {
+		name, _, _ := strings.Cut(config.Field(i).Tag.Get("json"), ",")
+		if _, ok := properties[name]; !ok {
+			t.Errorf("%s is missing from the schema", name)
+		}
+	}
+
+	keybinds := properties["keybinds"].(map[string]any)
+	bind := keybinds["items"].(map[string]any)["properties"].(map[string]any)
+	if keybinds["type"] != "array" || bind["dispatcher"].(map[string]any)["type"] != "string" {
+		t.Errorf("unexpected schema for keybinds: %v", keybinds)
+	}
+	if _, ok := bind["Statement"]; ok {
+		t.Error("the statement a bind is declared with is not part of its JSON representation")
+	}
+	points := properties["beziers"].(map[string]any)["items"].(map[string]any)["properties"].(map[string]any)["points"].(map[string]any)
+	if points["minItems"] != 4 || points["maxItems"] != 4 || points["items"].(map[string]any)["type"] != "number" {
+		t.Errorf("unexpected schema for the points of beziers: %v", points)
+	}
+	if properties["execs"].(map[string]any)["description"] == "" {
+		t.Error("execs should be described")
+	}
+
+	if _, err := json.Marshal(properties); err != nil {
+		t.Fatalf("schema cannot be encoded: %s", err)
+	}
+}
@@ -0,0 +1,228 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FileReader returns the contents of the file at path
+type FileReader func(path string) (string, error)
+
+// ConfigFile is one of the files of a configuration. Configurations can span multiple files with source statements.
+type ConfigFile struct {
+	Path     string
+	Contents string
+	Document Section
+	// Sources maps the line of every source statement of the file to the paths of the files it includes
+	Sources map[int][]string
+}
+
+// SourceError is returned when a file included by a source statement cannot be loaded
+type SourceError struct {
+	// File is the path of the file that contains the source statement
+	File      string
+	Statement Statement
+	Err       error
+}
+
+func (e SourceError) Error() string {
+	return fmt.Sprintf("%s:%d: while sourcing %s: %s", e.File, e.Statement.Position.Line+1, strings.TrimSpace(e.Statement.ValueRaw), e.Err)
+}
+
+func (e SourceError) Unwrap() error {
+	return e.Err
+}
+
+// Graph is a configuration made of a root file and all the files it includes, recursively
+type Graph struct {
+	Root  string
+	Files map[string]*ConfigFile
+	// Errors are the source statements that could not be followed
+	Errors []SourceError
+}
+
+// Entry is an assignment, custom variable definition or statement of a configuration file
+type Entry struct {
+	File *ConfigFile
+	// SectionPath is the path of the section the entry is in, e.g. ["decoration", "blur"]. It is empty at the root of a file.
+	SectionPath []string
+	Assignment  *Assignment
+	Variable    *CustomVariable
+	Statement   *Statement
+}
+
+func (e Entry) Position() Position {
+	switch {
+	case e.Assignment != nil:
+		return e.Assignment.Position
+	case e.Variable != nil:
+		return e.Variable.Position
+	case e.Statement != nil:
+		return e.Statement.Position
+	default:
+		return Position{}
+	}
+}
+
+// LoadGraph loads the configuration file at root and all the files it includes.
+// Files that cannot be included do not stop the loading, they are reported in the graph's Errors instead.
+func LoadGraph(root string, read FileReader) (*Graph, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("while resolving path of %s: %w", root, err)
+	}
+
+	graph := &Graph{
+		Root:  root,
+		Files: make(map[string]*ConfigFile),
+	}
+	if err := graph.load(root, read, make(map[string]string)); err != nil {
+		return nil, err
+	}
+	return graph, nil
+}
+
+func (g *Graph) load(path string, read FileReader, variables map[string]string) error {
+	contents, err := read(path)
+	if err != nil {
+		return err
+	}
+
+	document, err := Parse(contents)
+	if err != nil {
+		return fmt.Errorf("while parsing %s: %w", path, err)
+	}
+
+	file := &ConfigFile{
+		Path:     path,
+		Contents: contents,
+		Document: document,
+		Sources:  make(map[int][]string),
+	}
+	g.Files[path] = file
+
+	for _, entry := range file.Entries() {
+		if entry.Variable != nil {
+			variables[entry.Variable.Key] = ExpandVariables(entry.Variable.ValueRaw, variables)
+		}
+		if entry.Statement == nil || entry.Statement.Keyword != "source" {
+			continue
+		}
+
+		stmt := *entry.Statement
+		paths, err := ResolveSourcePath(ExpandVariables(stmt.ValueRaw, variables), filepath.Dir(path))
+		if err != nil {
+			g.Errors = append(g.Errors, SourceError{File: path, Statement: stmt, Err: err})
+			continue
+		}
+		file.Sources[stmt.Position.Line] = paths
+
+		for _, included := range paths {
+			if _, loaded := g.Files[included]; loaded {
+				continue
+			}
+			if err := g.load(included, read, variables); err != nil {
+				g.Errors = append(g.Errors, SourceError{File: path, Statement: stmt, Err: err})
+			}
+		}
+	}
+	return nil
+}
+
+// ResolveSourcePath returns the paths of the files a source statement includes.
+// ~ is expanded to the home directory, relative paths are resolved from the directory relativeTo, and glob patterns are expanded.
+func ResolveSourcePath(raw string, relativeTo string) ([]string, error) {
+	path := strings.TrimSpace(raw)
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("while expanding ~: %w", err)
+		}
+		path = filepath.Join(home, strings.TrimPrefix(path, "~"))
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(relativeTo, path)
+	}
+
+	if !strings.ContainsAny(path, "*?[") {
+		return []string{filepath.Clean(path)}, nil
+	}
+
+	matches, err := filepath.Glob(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid glob pattern: %w", err)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no files match %s", path)
+	}
+	return matches, nil
+}
+
+// Entries returns all the entries of the file, in the order they appear in the file
+func (f *ConfigFile) Entries() []Entry {
+	entries := make([]Entry, 0)
+	var collect func(section Section, path []string)
+	collect = func(section Section, path []string) {
+		for i := range section.Assignments {
+			entries = append(entries, Entry{File: f, SectionPath: path, Assignment: &section.Assignments[i]})
+		}
+		for i := range section.Variables {
+			entries = append(entries, Entry{File: f, SectionPath: path, Variable: &section.Variables[i]})
+		}
+		for i := range section.Statements {
+			entries = append(entries, Entry{File: f, SectionPath: path, Statement: &section.Statements[i]})
+		}
+		for _, sub := range section.Subsections {
+			collect(sub, append(append([]string{}, path...), sub.Name))
+		}
+	}
+	collect(f.Document, []string{})
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Position().Line < entries[j].Position().Line
+	})
+	return entries
+}
+
+// Walk calls f on every entry of the configuration, in the order Hyprland evaluates them:
+// the entries of files included by a source statement are walked right after that statement.
+func (g *Graph) Walk(f func(entry Entry)) {
+	g.walkFile(g.Root, f, make(map[string]bool))
+}
+
+func (g *Graph) walkFile(path string, f func(entry Entry), walking map[string]bool) {
+	file, ok := g.Files[path]
+	// Don't walk into files that include themselves, directly or not
+	if !ok || walking[path] {
+		return
+	}
+
+	walking[path] = true
+	defer delete(walking, path)
+
+	for _, entry := range file.Entries() {
+		f(entry)
+		if entry.Statement == nil {
+			continue
+		}
+		for _, included := range file.Sources[entry.Statement.Position.Line] {
+			g.walkFile(included, f, walking)
+		}
+	}
+}
+
+// SortedFiles returns the files of the graph, sorted by path
+func (g *Graph) SortedFiles() []*ConfigFile {
+	files := make([]*ConfigFile, 0, len(g.Files))
+	for _, file := range g.Files {
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	return files
+}
@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+)
+
+func TestGraphWalk(t *testing.T) {
+	files := map[string]string{
+		"/hypr/hyprland.conf": heredoc.Doc(`
+			$dir = /hypr/conf.d
+			general {
+				gaps_in = 5
+			}
+			source = $dir/*.conf
+			source = ./hyprland.conf
+			gaps_out = 10
+		`),
+		"/hypr/conf.d/binds.conf": "bind = SUPER, Q, killactive",
+	}
+
+	graph, err := LoadGraph("/hypr/hyprland.conf", func(path string) (string, error) {
+		if contents, ok := files[path]; ok {
+			return contents, nil
+		}
+		return "", fmt.Errorf("open %s: %w", path, os.ErrNotExist)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The glob can't match anything since the files are not on disk
+	if len(graph.Errors) != 1 || graph.Errors[0].Statement.Position.Line != 4 {
+		t.Fatalf("expected an error on the glob source, got %v", graph.Errors)
+	}
+
+	graph.Files["/hypr/hyprland.conf"].Sources[4] = []string{"/hypr/conf.d/binds.conf"}
+	graph.Files["/hypr/conf.d/binds.conf"] = &ConfigFile{Path: "/hypr/conf.d/binds.conf"}
+	graph.Files["/hypr/conf.d/binds.conf"].Document, _ = Parse(files["/hypr/conf.d/binds.conf"])
+
+	walked := make([]string, 0)
+	graph.Walk(func(entry Entry) {
+		walked = append(walked, fmt.Sprintf("%s:%d", entry.File.Path, entry.Position().Line))
+	})
+	expected := "[/hypr/hyprland.conf:0 /hypr/hyprland.conf:2 /hypr/hyprland.conf:4 /hypr/conf.d/binds.conf:0 /hypr/hyprland.conf:5 /hypr/hyprland.conf:6]"
+	if fmt.Sprint(walked) != expected {
+		t.Errorf("unexpected walk order:\n%v\nexpected:\n%v", walked, expected)
+	}
+}
+
+func TestExpandVariables(t *testing.T) {
+	t.Setenv("HYPRLS_TEST_TERMINAL", "kitty")
+	variables := map[string]string{"main": "SUPER", "mainMod": "ALT"}
+	expanded := ExpandVariables("$mainMod SHIFT, $main, $HYPRLS_TEST_TERMINAL, $unknown", variables)
+	if expanded != "ALT SHIFT, SUPER, kitty, $unknown" {
+		t.Errorf("unexpected expansion: %q", expanded)
+	}
+}
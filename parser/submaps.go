@@ -0,0 +1,102 @@
+package parser
+
+import "strings"
+
+// SubmapReset is the submap name that goes back to the global submap
+const SubmapReset = "reset"
+
+// Submap is a region of statements opened by "submap = name" and closed by "submap = reset".
+// Reference: https://wiki.hyprland.org/Configuring/Binds/#submaps
+type Submap struct {
+	Name string `json:"name"`
+	// Opening is the "submap = name" statement
+	Opening Statement `json:"opening"`
+	// Reset is the "submap = reset" statement that closes the submap. It is nil if the submap is never closed.
+	Reset *Statement `json:"reset,omitempty"`
+	// Statements are all the statements declared inside the submap
+	Statements []Statement `json:"stmt"`
+}
+
+// Binds returns all the valid binds declared inside of the submap
+func (s Submap) Binds() []Bind {
+	binds := make([]Bind, 0)
+	for _, stmt := range s.Statements {
+		if bind, err := ParseBind(stmt); err == nil {
+			binds = append(binds, bind)
+		}
+	}
+	return binds
+}
+
+// Submaps returns all submaps declared in the section and its subsections, in order of declaration.
+// A submap name can appear multiple times if the submap is opened more than once.
+func (s Section) Submaps() []Submap {
+	submaps := make([]Submap, 0)
+	var current *Submap
+	for _, stmt := range s.Statements {
+		if stmt.Keyword != "submap" {
+			if current != nil {
+				current.Statements = append(current.Statements, stmt)
+			}
+			continue
+		}
+
+		if current != nil {
+			if SubmapName(stmt) == SubmapReset {
+				current.Reset = &stmt
+			}
+			submaps = append(submaps, *current)
+			current = nil
+		}
+
+		if SubmapName(stmt) != SubmapReset {
+			current = &Submap{
+				Name:       SubmapName(stmt),
+				Opening:    stmt,
+				Statements: make([]Statement, 0),
+			}
+		}
+	}
+	if current != nil {
+		submaps = append(submaps, *current)
+	}
+
+	for _, sub := range s.Subsections {
+		submaps = append(submaps, sub.Submaps()...)
+	}
+	return submaps
+}
+
+// WalkStatementsInSubmaps calls f for every statement of the section and its subsections, along with the name of the submap the statement is in ("" if it's not in any submap).
+// Submap statements themselves are not walked.
+func (s Section) WalkStatementsInSubmaps(f func(stmt Statement, submap string)) {
+	current := ""
+	for _, stmt := range s.Statements {
+		if stmt.Keyword == "submap" {
+			current = SubmapName(stmt)
+			if current == SubmapReset {
+				current = ""
+			}
+			continue
+		}
+		f(stmt, current)
+	}
+	for _, sub := range s.Subsections {
+		sub.WalkStatementsInSubmaps(f)
+	}
+}
+
+// WalkStatements calls f for every statement of the section and its subsections
+func (s Section) WalkStatements(f func(stmt *Statement)) {
+	for _, stmt := range s.Statements {
+		f(&stmt)
+	}
+	for _, sub := range s.Subsections {
+		sub.WalkStatements(f)
+	}
+}
+
+// SubmapName returns the name of the submap a "submap = name" statement switches to
+func SubmapName(stmt Statement) string {
+	return strings.TrimSpace(stmt.ValueRaw)
+}
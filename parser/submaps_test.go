@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+)
+
+func TestSubmaps(t *testing.T) {
+	document, err := Parse(heredoc.Doc(`
+		bind = ALT, R, submap, resize
+		submap = resize
+		binde = , right, resizeactive, 10 0
+		bind = , escape, submap, reset
+		submap = reset
+
+		submap = passthru
+		bind = SUPER, Q, exec, notify-send "a, b"
+	`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	submaps := document.Submaps()
+	if len(submaps) != 2 {
+		t.Fatalf("expected 2 submaps, got %d", len(submaps))
+	}
+
+	resize := submaps[0]
+	if resize.Name != "resize" || resize.Reset == nil || resize.Reset.Position.Line != 4 {
+		t.Errorf("unexpected resize submap: %#v", resize)
+	}
+	if binds := resize.Binds(); len(binds) != 2 || binds[1].Dispatcher != "submap" || binds[1].Params != SubmapReset {
+		t.Errorf("unexpected binds in resize submap: %#v", binds)
+	}
+
+	passthru := submaps[1]
+	if passthru.Reset != nil {
+		t.Errorf("expected passthru submap to not be closed")
+	}
+	if binds := passthru.Binds(); len(binds) != 1 || binds[0].Params != `notify-send "a, b"` {
+		t.Errorf("expected commas to be kept in bind parameters, got %#v", binds)
+	}
+
+	inSubmap := make(map[int]string)
+	document.WalkStatementsInSubmaps(func(stmt Statement, submap string) {
+		inSubmap[stmt.Position.Line] = submap
+	})
+	if inSubmap[0] != "" || inSubmap[2] != "resize" || inSubmap[7] != "passthru" {
+		t.Errorf("unexpected submaps for statements: %v", inSubmap)
+	}
+}
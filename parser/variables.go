@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ExpandVariables replaces custom variables (e.g. $mainMod) in raw with their values.
+// Like Hyprland, the longest variable name that matches is used, and if no custom variable matches, environment variables are tried.
+func ExpandVariables(raw string, variables map[string]string) string {
+	if !strings.Contains(raw, "$") {
+		return raw
+	}
+
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return len(names[i]) > len(names[j])
+	})
+
+	var expanded strings.Builder
+	for i := 0; i < len(raw); i++ {
+		if raw[i] != '$' {
+			expanded.WriteByte(raw[i])
+			continue
+		}
+
+		matched := false
+		for _, name := range names {
+			if strings.HasPrefix(raw[i+1:], name) {
+				expanded.WriteString(variables[name])
+				i += len(name)
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+
+		name := variableNameAt(raw[i+1:])
+		if value, ok := os.LookupEnv(name); ok && name != "" {
+			expanded.WriteString(value)
+			i += len(name)
+			continue
+		}
+		expanded.WriteByte(raw[i])
+	}
+	return expanded.String()
+}
+
+// variableNameAt returns the variable name that starts at the beginning of s
+func variableNameAt(s string) string {
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+	if end == -1 {
+		return s
+	}
+	return s[:end]
+}
@@ -1,29 +1,137 @@
 package hyprls
 
 import (
-	"context"
+	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/ewen-lbh/hyprls/parser"
 	parser_data "github.com/ewen-lbh/hyprls/parser/data"
 	"go.lsp.dev/protocol"
-	"go.uber.org/zap"
+	"go.lsp.dev/uri"
 )
 
-var logger *zap.Logger
+// state is the state of the server for a single client connection
+type state struct {
+	mu sync.RWMutex
+	// openedFiles maps the files opened by the client to their current contents
+	openedFiles map[protocol.URI]string
+	// graphs caches the configuration each opened file is part of, see configGraph.
+	// A change to any file can change the configuration of the others, so the cache is emptied whenever a file changes.
+	graphs map[protocol.URI]*parser.Graph
+	// generation is incremented every time graphs is emptied, so that a graph that was loaded during a change is not cached
+	generation int
+	// mainConfig is the path of the main Hyprland configuration file, see MainConfigPath
+	mainConfig string
+	settings   settings
+}
 
-var openedFiles = make(map[protocol.URI]string)
+// settings are the settings sent by the client, in the initialization options or in the hyprls section of its configuration
+type settings struct {
+	// HyprlandVersion is the Hyprland version configurations target when their file doesn't declare one. Empty means any version.
+	HyprlandVersion string `json:"hyprlandVersion"`
+}
 
-type state struct {
+// parseSettings parses the settings sent by the client, which can be nested in a hyprls object
+func parseSettings(raw any) (settings, error) {
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return settings{}, err
+	}
+	var nested struct {
+		Hyprls *settings `json:"hyprls"`
+	}
+	var parsed settings
+	if err := json.Unmarshal(encoded, &nested); err == nil && nested.Hyprls != nil {
+		parsed = *nested.Hyprls
+	} else if err := json.Unmarshal(encoded, &parsed); err != nil {
+		return settings{}, err
+	}
+
+	if parsed.HyprlandVersion != "" {
+		parsed.HyprlandVersion, err = parser_data.ParseVersion(parsed.HyprlandVersion)
+		if err != nil {
+			return settings{}, fmt.Errorf("invalid hyprlandVersion setting: %w", err)
+		}
+	}
+	return parsed, nil
+}
+
+func (s *state) configure(settings settings) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settings = settings
+}
+
+// openedURIs returns the URIs of the files opened by the client
+func (s *state) openedURIs() []protocol.URI {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	uris := make([]protocol.URI, 0, len(s.openedFiles))
+	for uri := range s.openedFiles {
+		uris = append(uris, uri)
+	}
+	return uris
+}
+
+// targetVersion returns the Hyprland version the file at uri targets: the one declared in the header of the file or of the configuration it is part of, or else the one of the settings.
+// It returns "" if the file targets any version.
+func (s *state) targetVersion(fileURI protocol.URI) string {
+	if graph, err := s.configGraph(fileURI); err == nil {
+		if version := graph.TargetVersion(fileURI.Filename()); version != "" {
+			return version
+		}
+	} else if contents, err := s.file(fileURI); err == nil {
+		if version := parser.TargetVersion(contents); version != "" {
+			return version
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.settings.HyprlandVersion
+}
+
+func newState() *state {
+	return &state{
+		openedFiles: make(map[protocol.URI]string),
+		graphs:      make(map[protocol.URI]*parser.Graph),
+		mainConfig:  MainConfigPath(),
+	}
+}
+
+func (s *state) open(uri protocol.URI, contents string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.openedFiles[uri] = contents
+	s.invalidateGraphs()
 }
 
-func (h Handler) state(ctx context.Context) state {
-	return ctx.Value("state").(state)
+func (s *state) close(uri protocol.URI) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.openedFiles, uri)
+	s.invalidateGraphs()
 }
 
-func parse(uri protocol.URI) (parser.Section, error) {
-	contents, err := file(uri)
+// filesChanged tells the state that files changed on disk, which may be part of the configuration of opened files
+func (s *state) filesChanged() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.invalidateGraphs()
+}
+
+// invalidateGraphs empties the cache of configuration graphs. s.mu must be locked.
+func (s *state) invalidateGraphs() {
+	clear(s.graphs)
+	s.generation++
+}
+
+func (s *state) parse(uri protocol.URI) (parser.Section, error) {
+	contents, err := s.file(uri)
 	if err != nil {
 		return parser.Section{}, err
 	}
@@ -31,6 +139,63 @@ func parse(uri protocol.URI) (parser.Section, error) {
 	return parser.Parse(contents)
 }
 
+// configGraph returns the configuration that the file at uri is part of.
+// If the main Hyprland configuration file sources that file, the configuration is loaded from the main file.
+// Otherwise, the file is the root of the configuration.
+// The configuration of opened files is cached until a file changes. It must not be modified.
+func (s *state) configGraph(fileURI protocol.URI) (*parser.Graph, error) {
+	s.mu.RLock()
+	graph, cached := s.graphs[fileURI]
+	generation := s.generation
+	s.mu.RUnlock()
+	if cached {
+		return graph, nil
+	}
+
+	graph, err := s.loadConfigGraph(fileURI)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, opened := s.openedFiles[fileURI]; opened && generation == s.generation {
+		s.graphs[fileURI] = graph
+	}
+	return graph, nil
+}
+
+// loadConfigGraph loads the configuration that the file at uri is part of, see configGraph
+func (s *state) loadConfigGraph(fileURI protocol.URI) (*parser.Graph, error) {
+	path := fileURI.Filename()
+	if mainConfig := s.mainConfig; mainConfig != "" && mainConfig != path {
+		graph, err := parser.LoadGraph(mainConfig, s.readConfigFile)
+		if err == nil {
+			if _, ok := graph.Files[path]; ok {
+				return graph, nil
+			}
+		}
+	}
+	return parser.LoadGraph(path, s.readConfigFile)
+}
+
+// readConfigFile reads a configuration file, using the contents sent by the client if the file is opened
+func (s *state) readConfigFile(path string) (string, error) {
+	return s.file(uri.File(path))
+}
+
+// MainConfigPath returns the path of the main Hyprland configuration file, or "" if it cannot be determined
+func MainConfigPath() string {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configHome, "hypr", "hyprland.conf")
+}
+
 func currentSection(root parser.Section, position protocol.Position) *parser.Section {
 	if !within(root.LSPRange(), position) {
 		return nil
@@ -60,6 +225,16 @@ func currentAssignment(root parser.Section, position protocol.Position) *parser_
 	return nil
 }
 
+func currentStatement(root parser.Section, position protocol.Position) *parser.Statement {
+	var found *parser.Statement
+	root.WalkStatements(func(stmt *parser.Statement) {
+		if stmt.Position.Line == int(position.Line) {
+			found = stmt
+		}
+	})
+	return found
+}
+
 func within(rang protocol.Range, position protocol.Position) bool {
 	if position.Line < rang.Start.Line || position.Line > rang.End.Line {
 		return false
@@ -76,26 +251,31 @@ func within(rang protocol.Range, position protocol.Position) bool {
 	return true
 }
 
-func file(uri protocol.URI) (string, error) {
-	if contents, ok := openedFiles[uri]; ok {
+// file returns the contents of the file at uri: the contents sent by the client if the file is opened, or the contents on disk otherwise
+func (s *state) file(uri protocol.URI) (string, error) {
+	s.mu.RLock()
+	contents, ok := s.openedFiles[uri]
+	s.mu.RUnlock()
+	if ok {
 		return contents, nil
 	}
 
-	contents, err := os.ReadFile(uri.Filename())
+	onDisk, err := os.ReadFile(uri.Filename())
 	if err != nil {
 		return "", err
 	}
-
-	openedFiles[uri] = string(contents)
-	return string(contents), nil
+	return string(onDisk), nil
 }
 
-func currentLine(uri protocol.URI, position protocol.Position) (string, error) {
-	contents, err := file(uri)
+func (s *state) currentLine(uri protocol.URI, position protocol.Position) (string, error) {
+	contents, err := s.file(uri)
 	if err != nil {
 		return "", err
 	}
 
 	lines := strings.Split(contents, "\n")
+	if int(position.Line) >= len(lines) {
+		return "", fmt.Errorf("line %d is after the end of the file, which has %d lines", position.Line+1, len(lines))
+	}
 	return lines[position.Line], nil
 }
@@ -0,0 +1,57 @@
+package hyprls
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.lsp.dev/uri"
+)
+
+func TestConfigGraphIsCachedUntilAFileChanges(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	sourced := filepath.Join(dir, "binds.conf")
+	if err := os.WriteFile(sourced, []byte("bind = SUPER, Q, killactive\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := newState()
+	if s.mainConfig != filepath.Join(dir, "hypr", "hyprland.conf") {
+		t.Errorf("unexpected main configuration %s", s.mainConfig)
+	}
+	document := uri.File(filepath.Join(dir, "config.conf"))
+	s.open(document, "source = "+sourced+"\n")
+
+	graph, err := s.configGraph(document)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := s.configGraph(document); again != graph {
+		t.Error("expected the configuration of an opened file to be cached")
+	}
+	if len(graph.Files) != 2 {
+		t.Errorf("expected the sourced file to be loaded, got %v", graph.Files)
+	}
+
+	s.open(document, "$changed = 1\n")
+	changed, err := s.configGraph(document)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed == graph || len(changed.Files) != 1 {
+		t.Errorf("expected the configuration to be loaded again after a change, got %v", changed.Files)
+	}
+
+	s.filesChanged()
+	if again, _ := s.configGraph(document); again == changed {
+		t.Error("expected the configuration to be loaded again after files changed on disk")
+	}
+
+	notOpened := uri.File(sourced)
+	if first, _ := s.configGraph(notOpened); first != nil {
+		if second, _ := s.configGraph(notOpened); second == first {
+			t.Error("expected the configuration of files that are not opened not to be cached")
+		}
+	}
+}
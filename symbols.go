@@ -3,13 +3,15 @@ package hyprls
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/ewen-lbh/hyprls/parser"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
 	"go.lsp.dev/protocol"
 )
 
 func (h Handler) DocumentSymbol(ctx context.Context, params *protocol.DocumentSymbolParams) ([]interface{}, error) {
-	document, err := parse(params.TextDocument.URI)
+	document, err := h.state.parse(params.TextDocument.URI)
 	if err != nil {
 		return nil, fmt.Errorf("while parsing: %w", err)
 	}
@@ -27,8 +29,8 @@ func gatherAllSymbols(root parser.Section) []protocol.DocumentSymbol {
 			Name:           variable.Key,
 			Kind:           variable.Value.Kind.LSPSymbol(),
 			Detail:         variable.ValueRaw,
-			Range:          collapsedRange(variable.Position.LSP()),
-			SelectionRange: collapsedRange(variable.Position.LSP()),
+			Range:          variable.LSPRange(),
+			SelectionRange: selectionRange(variable.Position, variable.Key),
 		})
 	}
 	for _, customVar := range root.Variables {
@@ -36,10 +38,11 @@ func gatherAllSymbols(root parser.Section) []protocol.DocumentSymbol {
 			Name:           "$" + customVar.Key,
 			Kind:           protocol.SymbolKindVariable,
 			Detail:         customVar.ValueRaw,
-			Range:          collapsedRange(customVar.Position.LSP()),
-			SelectionRange: collapsedRange(customVar.Position.LSP()),
+			Range:          customVar.LSPRange(),
+			SelectionRange: selectionRange(customVar.Position, "$"+customVar.Key),
 		})
 	}
+	symbols = append(symbols, gatherStatementSymbols(root.Statements)...)
 	for _, section := range root.Subsections {
 		symbols = append(symbols, protocol.DocumentSymbol{
 			Name:           section.Name,
@@ -51,3 +54,137 @@ func gatherAllSymbols(root parser.Section) []protocol.DocumentSymbol {
 	}
 	return symbols
 }
+
+// gatherStatementSymbols returns symbols for the given statements.
+// Statements that are between a "submap = name" and a "submap = reset" are nested inside a symbol for the submap.
+func gatherStatementSymbols(statements []parser.Statement) []protocol.DocumentSymbol {
+	symbols := make([]protocol.DocumentSymbol, 0)
+	var submap *protocol.DocumentSymbol
+	closeSubmap := func() {
+		if submap != nil {
+			symbols = append(symbols, *submap)
+			submap = nil
+		}
+	}
+
+	for _, stmt := range statements {
+		if stmt.Keyword == "submap" {
+			name := parser.SubmapName(stmt)
+			if submap != nil {
+				submap.Range.End = stmt.End.LSP()
+			}
+			closeSubmap()
+			if name == parser.SubmapReset {
+				continue
+			}
+			submap = &protocol.DocumentSymbol{
+				Name:           name,
+				Detail:         "submap",
+				Kind:           protocol.SymbolKindNamespace,
+				Range:          stmt.LSPRange(),
+				SelectionRange: selectionRange(stmt.Position, string(stmt.Keyword)),
+				Children:       make([]protocol.DocumentSymbol, 0),
+			}
+			continue
+		}
+
+		if submap != nil {
+			submap.Children = append(submap.Children, statementSymbol(stmt))
+			submap.Range.End = stmt.End.LSP()
+		} else {
+			symbols = append(symbols, statementSymbol(stmt))
+		}
+	}
+	closeSubmap()
+	return symbols
+}
+
+func statementSymbol(stmt parser.Statement) protocol.DocumentSymbol {
+	name, detail := statementSymbolNameAndDetail(stmt)
+	if name == "" {
+		name = string(stmt.Keyword)
+	}
+	return protocol.DocumentSymbol{
+		Name:           name,
+		Detail:         detail,
+		Kind:           statementSymbolKind(stmt),
+		Range:          stmt.LSPRange(),
+		SelectionRange: selectionRange(stmt.Position, string(stmt.Keyword)),
+	}
+}
+
+// statementSymbolNameAndDetail returns a meaningful name for a statement's symbol (the key combination for a bind, the monitor's name, the target of a rule, etc.), along with a detail string that contains the rest of the statement.
+func statementSymbolNameAndDetail(stmt parser.Statement) (name string, detail string) {
+	args := stmt.RawArguments()
+	rest := func(from int) string {
+		if from >= len(args) {
+			return ""
+		}
+		return strings.Join(args[from:], ", ")
+	}
+
+	keyword, _ := parser_data.FindKeyword(string(stmt.Keyword))
+	switch keyword.Name {
+	case "bind":
+		return bindComboName(args), strings.TrimSuffix(rest(2), ", ")
+	case "unbind":
+		return bindComboName(args), string(stmt.Keyword)
+	case "monitor":
+		if args[0] == "" {
+			return "(any monitor)", rest(1)
+		}
+		return args[0], rest(1)
+	case "windowrule", "windowrulev2", "layerrule":
+		return rest(1), args[0]
+	case "workspace", "env", "envd", "animation", "bezier":
+		return args[0], rest(1)
+	default:
+		return strings.TrimSpace(stmt.ValueRaw), string(stmt.Keyword)
+	}
+}
+
+// bindComboName returns a human-readable key combination from a bind's arguments, e.g. "SUPER SHIFT + Q"
+func bindComboName(args []string) string {
+	if len(args) < 2 {
+		return strings.Join(args, " + ")
+	}
+	if args[0] == "" {
+		return args[1]
+	}
+	return args[0] + " + " + args[1]
+}
+
+func statementSymbolKind(stmt parser.Statement) protocol.SymbolKind {
+	keyword, _ := parser_data.FindKeyword(string(stmt.Keyword))
+	switch keyword.Name {
+	case "bind", "unbind":
+		return protocol.SymbolKindKey
+	case "monitor":
+		return protocol.SymbolKindObject
+	case "exec", "exec-once", "execr", "execr-once", "exec-shutdown":
+		return protocol.SymbolKindEvent
+	case "windowrule", "windowrulev2", "layerrule", "workspace":
+		return protocol.SymbolKindProperty
+	case "env":
+		return protocol.SymbolKindVariable
+	case "animation":
+		return protocol.SymbolKindEvent
+	case "bezier":
+		return protocol.SymbolKindFunction
+	case "source":
+		return protocol.SymbolKindFile
+	default:
+		return protocol.SymbolKindField
+	}
+}
+
+// selectionRange returns the range spanning word, starting at start
+func selectionRange(start parser.Position, word string) protocol.Range {
+	return protocol.Range{
+		Start: start.LSP(),
+		End: protocol.Position{
+			Line:      uint32(start.Line),
+			Character: uint32(start.Column + len(word)),
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package hyprls
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/ewen-lbh/hyprls/parser"
+	"go.lsp.dev/protocol"
+)
+
+func TestStatementSymbols(t *testing.T) {
+	document, err := parser.Parse(heredoc.Doc(`
+		monitor = DP-1, 1920x1080, 0x0, 1
+		bind = SUPER, Q, killactive,
+		bind = SUPER, R, submap, resize
+
+		submap = resize
+		binde = , right, resizeactive, 10 0
+		bind = , escape, submap, reset
+		submap = reset
+
+		windowrulev2 = float, class:(kitty)
+	`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	symbols := gatherAllSymbols(document)
+	expected := []struct {
+		name   string
+		detail string
+		kind   protocol.SymbolKind
+	}{
+		{"DP-1", "1920x1080, 0x0, 1", protocol.SymbolKindObject},
+		{"SUPER + Q", "killactive", protocol.SymbolKindKey},
+		{"SUPER + R", "submap, resize", protocol.SymbolKindKey},
+		{"resize", "submap", protocol.SymbolKindNamespace},
+		{"class:(kitty)", "float", protocol.SymbolKindProperty},
+	}
+	if len(symbols) != len(expected) {
+		t.Fatalf("expected %d symbols, got %d: %#v", len(expected), len(symbols), symbols)
+	}
+	for i, exp := range expected {
+		if symbols[i].Name != exp.name || symbols[i].Detail != exp.detail || symbols[i].Kind != exp.kind {
+			t.Errorf("symbol %d: expected %q (%q, kind %v), got %q (%q, kind %v)", i, exp.name, exp.detail, exp.kind, symbols[i].Name, symbols[i].Detail, symbols[i].Kind)
+		}
+	}
+
+	submap := symbols[3]
+	if len(submap.Children) != 2 {
+		t.Fatalf("expected 2 binds inside of submap, got %d", len(submap.Children))
+	}
+	if submap.Range.Start.Line != 4 || submap.Range.End.Line != 7 {
+		t.Errorf("expected submap to span lines 4 to 7, got %d to %d", submap.Range.Start.Line, submap.Range.End.Line)
+	}
+	if submap.Children[0].Name != "right" {
+		t.Errorf("expected bind without modifiers to be named after its key, got %q", submap.Children[0].Name)
+	}
+}
@@ -8,20 +8,30 @@ import (
 	"go.uber.org/zap"
 )
 
-
 func (h Handler) DidChange(ctx context.Context, params *protocol.DidChangeTextDocumentParams) error {
-	logger.Debug("LSP:DidChange", zap.Any("params", params))
-	openedFiles[params.TextDocument.URI] = params.ContentChanges[len(params.ContentChanges)-1].Text
+	h.Logger.Debug("LSP:DidChange", zap.Any("params", params))
+	h.state.open(params.TextDocument.URI, params.ContentChanges[len(params.ContentChanges)-1].Text)
+	h.publishDiagnostics(ctx, params.TextDocument.URI)
 	return nil
 }
 
 func (h Handler) DidClose(ctx context.Context, params *protocol.DidCloseTextDocumentParams) error {
-	delete(openedFiles, params.TextDocument.URI)
+	h.state.close(params.TextDocument.URI)
 	return nil
 }
 
 func (h Handler) DidOpen(ctx context.Context, params *protocol.DidOpenTextDocumentParams) error {
-	file(params.TextDocument.URI)
+	h.state.open(params.TextDocument.URI, params.TextDocument.Text)
+	h.publishDiagnostics(ctx, params.TextDocument.URI)
+	return nil
+}
+
+// DidChangeWatchedFiles is sent when files change on disk. They may be sourced by opened files, whose diagnostics are published again.
+func (h Handler) DidChangeWatchedFiles(ctx context.Context, params *protocol.DidChangeWatchedFilesParams) error {
+	h.state.filesChanged()
+	for _, uri := range h.state.openedURIs() {
+		h.publishDiagnostics(ctx, uri)
+	}
 	return nil
 }
 
@@ -7,10 +7,6 @@ import (
 	"go.lsp.dev/protocol"
 )
 
-func (h Handler) Definition(ctx context.Context, params *protocol.DefinitionParams) ([]protocol.Location, error) {
-	return []protocol.Location{}, errors.New("unimplemented")
-}
-
 func (h Handler) WorkDoneProgressCancel(ctx context.Context, params *protocol.WorkDoneProgressCancelParams) error {
 	return errors.New("unimplemented")
 }
@@ -23,10 +19,6 @@ func (h Handler) SetTrace(ctx context.Context, params *protocol.SetTraceParams)
 	return errors.New("unimplemented")
 }
 
-func (h Handler) CodeAction(ctx context.Context, params *protocol.CodeActionParams) ([]protocol.CodeAction, error) {
-	return nil, errors.New("unimplemented")
-}
-
 func (h Handler) CodeLens(ctx context.Context, params *protocol.CodeLensParams) ([]protocol.CodeLens, error) {
 	return nil, errors.New("unimplemented")
 }
@@ -39,14 +31,6 @@ func (h Handler) Declaration(ctx context.Context, params *protocol.DeclarationPa
 	return nil, errors.New("unimplemented")
 }
 
-func (h Handler) DidChangeConfiguration(ctx context.Context, params *protocol.DidChangeConfigurationParams) error {
-	return errors.New("unimplemented")
-}
-
-func (h Handler) DidChangeWatchedFiles(ctx context.Context, params *protocol.DidChangeWatchedFilesParams) error {
-	return errors.New("unimplemented")
-}
-
 func (h Handler) DidChangeWorkspaceFolders(ctx context.Context, params *protocol.DidChangeWorkspaceFoldersParams) error {
 	return errors.New("unimplemented")
 }
@@ -63,18 +47,10 @@ func (h Handler) DocumentLinkResolve(ctx context.Context, params *protocol.Docum
 	return nil, errors.New("unimplemented")
 }
 
-func (h Handler) ExecuteCommand(ctx context.Context, params *protocol.ExecuteCommandParams) (interface{}, error) {
-	return nil, errors.New("unimplemented")
-}
-
 func (h Handler) FoldingRanges(ctx context.Context, params *protocol.FoldingRangeParams) ([]protocol.FoldingRange, error) {
 	return nil, errors.New("unimplemented")
 }
 
-func (h Handler) Formatting(ctx context.Context, params *protocol.DocumentFormattingParams) ([]protocol.TextEdit, error) {
-	return nil, errors.New("unimplemented")
-}
-
 func (h Handler) Implementation(ctx context.Context, params *protocol.ImplementationParams) ([]protocol.Location, error) {
 	return nil, errors.New("unimplemented")
 }
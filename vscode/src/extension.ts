@@ -16,16 +16,30 @@ let client: LanguageClient
 
 export function activate(context: ExtensionContext) {
   const serverModule = "hyprls"
+  // The documentation is shared by every client of a server, so it can only be chosen when the server starts
+  const docsPath = workspace.getConfiguration("hyprls").get<string>("docsPath")
+  const docsArgs = docsPath ? ["--docs-dir", docsPath] : []
 
   // If the extension is launched in debug mode then the debug server options are used
   // Otherwise the run options are used
   const serverOptions: ServerOptions = {
     run: {
       command: serverModule,
+      args: ["serve", ...docsArgs],
       transport: TransportKind.stdio,
     },
     debug: {
       command: "/home/uwun/projects/hyprls/hyprlang-lsp",
+      args: [
+        "serve",
+        "--log-level",
+        "debug",
+        "--log-file",
+        "/home/uwun/projects/hyprls/logs/server.log",
+        "--trace-rpc",
+        "/home/uwun/projects/hyprls/logs",
+        ...docsArgs,
+      ],
       transport: TransportKind.stdio,
     },
   }
@@ -35,8 +49,12 @@ export function activate(context: ExtensionContext) {
     // Register the server for plain text documents
     documentSelector: [{ scheme: "file", language: "hyprlang" }],
     outputChannelName: "HyprLS",
+    initializationOptions: {
+      hyprlandVersion: workspace.getConfiguration("hyprls").get("hyprlandVersion"),
+    },
     synchronize: {
       fileEvents: workspace.createFileSystemWatcher("*.hl"),
+      configurationSection: "hyprls",
     },
   }
 
@@ -0,0 +1,110 @@
+package hyprls
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"github.com/ewen-lbh/hyprls/parser/data/wiki"
+)
+
+// documentationDir is the directory of the wiki pages the documentation currently in use was scraped from, or "" for the documentation compiled into hyprls
+var documentationDir string
+
+// LoadDocumentation replaces the documentation compiled into hyprls with the one scraped from the wiki pages in dir,
+// which can be a checkout of hyprland-wiki, or a directory that contains the markdown files of its Configuring pages.
+// The scraped documentation is cached in the user's cache directory, keyed by a hash of the pages, so that they are only scraped again when they change.
+// If the pages cannot be scraped, the documentation compiled into hyprls is used and the error is returned.
+// The documentation is shared by every client and read without synchronization, so this must be called before the server starts.
+func LoadDocumentation(dir string) error {
+	pagesDir, err := wikiPagesDir(dir)
+	if err == nil && pagesDir == documentationDir {
+		return nil
+	}
+	var documentation parser_data.Documentation
+	if err == nil {
+		documentation, err = scrapeWithCache(os.DirFS(pagesDir))
+	}
+	if err != nil {
+		parser_data.UseEmbeddedDocumentation()
+		documentationDir = ""
+		return fmt.Errorf("while loading the documentation from %s: %w", dir, err)
+	}
+
+	parser_data.UseDocumentation(documentation)
+	documentationDir = pagesDir
+	return nil
+}
+
+// wikiPagesDir returns the directory that contains the markdown files of the Configuring pages of the wiki in dir
+func wikiPagesDir(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for _, candidate := range []string{dir, filepath.Join(dir, "pages", "Configuring"), filepath.Join(dir, "content", "Configuring")} {
+		if _, err := os.Stat(filepath.Join(candidate, "Variables.md")); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("no Variables.md in %s, nor in its pages/Configuring or content/Configuring directories", dir)
+}
+
+// scrapeWithCache scrapes the pages, or reads the result of a previous scraping of the same pages from the cache
+func scrapeWithCache(pages fs.FS) (parser_data.Documentation, error) {
+	hash, err := hashPages(pages)
+	if err != nil {
+		return parser_data.Documentation{}, err
+	}
+
+	cachePath := ""
+	if cacheDir, err := os.UserCacheDir(); err == nil {
+		cachePath = filepath.Join(cacheDir, "hyprls", "documentation", hash+".json")
+		if cached, err := os.ReadFile(cachePath); err == nil {
+			var documentation parser_data.Documentation
+			if err := json.Unmarshal(cached, &documentation); err == nil {
+				return documentation, nil
+			}
+		}
+	}
+
+	documentation, err := wiki.Parse(pages)
+	if err != nil {
+		return parser_data.Documentation{}, err
+	}
+
+	// The cache is only an optimization, failing to write it is not a problem
+	if cachePath != "" {
+		if encoded, err := json.Marshal(documentation); err == nil {
+			if os.MkdirAll(filepath.Dir(cachePath), 0o755) == nil {
+				os.WriteFile(cachePath, encoded, 0o644)
+			}
+		}
+	}
+	return documentation, nil
+}
+
+// hashPages returns a hash of the markdown files of pages and of the version of hyprls, since another version might scrape them differently
+func hashPages(pages fs.FS) (string, error) {
+	files, err := fs.Glob(pages, "*.md")
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\x00", Version)
+	for _, name := range files {
+		contents, err := fs.ReadFile(pages, name)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(hash, "%s\x00%d\x00", name, len(contents))
+		hash.Write(contents)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,85 @@
+package hyprls
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"github.com/ewen-lbh/hyprls/parser/data/wiki"
+)
+
+func TestLoadDocumentation(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Cleanup(func() {
+		parser_data.UseEmbeddedDocumentation()
+		documentationDir = ""
+	})
+
+	// A checkout of the wiki, with an option that hyprls doesn't know about
+	checkout := t.TempDir()
+	pagesDir := filepath.Join(checkout, "pages", "Configuring")
+	if err := os.MkdirAll(pagesDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	pages := wiki.EmbeddedPages(parser_data.Versions[0])
+	names, _ := fs.Glob(pages, "*.md")
+	for _, name := range names {
+		contents, _ := fs.ReadFile(pages, name)
+		if name == "Variables.md" {
+			contents = []byte(strings.Replace(string(contents), "| gaps_in |", "| gaps_sideways | gaps on the sides | int | 3 |\n| gaps_in |", 1))
+		}
+		if err := os.WriteFile(filepath.Join(pagesDir, name), contents, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := LoadDocumentation(checkout); err != nil {
+		t.Fatal(err)
+	}
+	if _, v := parser_data.FindVariableByPath("general:gaps_sideways"); v == nil || v.Default != "3" {
+		t.Fatalf("expected the documentation to be loaded from the checkout, got %+v", v)
+	}
+	cached, _ := filepath.Glob(filepath.Join(os.Getenv("XDG_CACHE_HOME"), "hyprls", "documentation", "*.json"))
+	if len(cached) != 1 {
+		t.Fatalf("expected the documentation to be cached, got %v", cached)
+	}
+
+	// The cache is used as long as the pages don't change
+	parser_data.UseEmbeddedDocumentation()
+	documentationDir = ""
+	if err := os.WriteFile(cached[0], []byte(`{"Sections": [{"Path": ["General"], "Variables": [{"Name": "from_cache"}]}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadDocumentation(pagesDir); err != nil {
+		t.Fatal(err)
+	}
+	if _, v := parser_data.FindVariableByPath("general:from_cache"); v == nil {
+		t.Error("expected the documentation to be read from the cache")
+	}
+
+	if err := LoadDocumentation(t.TempDir()); err == nil {
+		t.Error("expected loading from a directory without wiki pages to fail")
+	}
+	if _, v := parser_data.FindVariableByPath("general:gaps_in"); v == nil {
+		t.Error("expected to fall back to the embedded documentation")
+	}
+
+	// Pages that cannot be scraped make it fall back to the embedded documentation too
+	variables, err := os.ReadFile(filepath.Join(pagesDir, "Variables.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	malformed := "| name | description | type | default |\n| --- | --- | --- | --- |\n| orphan | not under a heading | int | 0 |\n\n" + string(variables)
+	if err := os.WriteFile(filepath.Join(pagesDir, "Variables.md"), []byte(malformed), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadDocumentation(checkout); err == nil {
+		t.Error("expected loading from malformed wiki pages to fail")
+	}
+	if _, v := parser_data.FindVariableByPath("general:gaps_in"); v == nil {
+		t.Error("expected to fall back to the embedded documentation")
+	}
+}